@@ -0,0 +1,295 @@
+package redblack
+
+import (
+	"html/template"
+	"io"
+)
+
+// An insert or delete for [WriteAnimation]
+type Operation[V Value] struct {
+	val    V
+	delete bool
+}
+
+// Insertion creates an operation that inserts the value
+func Insertion[V Value](v V) Operation[V] {
+	return Operation[V]{val: v}
+}
+
+// Deletion creates an operation that deletes the value
+func Deletion[V Value](v V) Operation[V] {
+	return Operation[V]{val: v, delete: true}
+}
+
+// Applies the operations to an empty tree and writes an HTML page
+// that animates them.
+//
+// Every insert is shown step by step as recorded by
+// [Tree.InsertTraced]: before each scenario, the red-red violation,
+// the uncle and the pivot of the rotation are highlighted. Deletes
+// are shown as the tree before and after. The page is a single file
+// with inline SVG and JavaScript, so it works offline.
+func WriteAnimation[V Value](w io.Writer, ops []Operation[V]) error {
+	frames := []animationFrame{}
+	tree := MakeTree[V]()
+	for _, op := range ops {
+		if op.delete {
+			frames = append(frames, makeAnimationFrame("Delete "+show(op.val), tree.node, map[string]string{show(op.val): markNew}))
+			if tree.Delete(op.val) {
+				frames = append(frames, makeAnimationFrame("Deleted "+show(op.val), tree.node, nil))
+			}
+			continue
+		}
+
+		steps := tree.InsertTraced(op.val)
+		if steps == nil {
+			frames = append(frames, makeAnimationFrame(show(op.val)+" is already in the tree", tree.node, nil))
+			continue
+		}
+		frames = append(frames, makeAnimationFrame(steps[0].Description(), steps[0].snapshot, map[string]string{show(op.val): markNew}))
+		for i, step := range steps[1:] {
+			before := steps[i].snapshot
+			frames = append(frames, makeAnimationFrame(step.Description(), before, animationMarks(step, before)))
+		}
+		if len(steps) > 1 {
+			frames = append(frames, makeAnimationFrame("Inserted "+show(op.val), tree.node, nil))
+		}
+	}
+
+	return animationTemplate.Execute(w, frames)
+}
+
+const (
+	markNew       = "new"
+	markViolation = "violation"
+	markUncle     = "uncle"
+	markPivot     = "pivot"
+)
+
+// Returns the highlights for the tree before the step, keyed by label
+func animationMarks[V Value](s Step[V], before *node[V, struct{}]) map[string]string {
+	marks := map[string]string{}
+	for _, r := range s.Rotations {
+		marks[show(r.Pivot)] = markPivot
+	}
+	if s.HasUncle {
+		marks[show(s.Uncle)] = markUncle
+	}
+	// a scenario is applied to a red node with a red parent,
+	// except for scenario 1, which is about the red root
+	marks[show(s.Node)] = markViolation
+	if n := seek(before, s.Node).match; s.Scenario != 1 && n != nil && n.p != nil {
+		marks[show(n.p.val)] = markViolation
+	}
+	return marks
+}
+
+// One picture of the animation
+type animationFrame struct {
+	Caption string          `json:"caption"`
+	Nodes   []animationNode `json:"nodes"`
+	Edges   [][2]string     `json:"edges"`
+}
+
+type animationNode struct {
+	Key  string  `json:"key"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Red  bool    `json:"red"`
+	Mark string  `json:"mark,omitempty"`
+}
+
+// Places the nodes in columns ordered by value and in rows by depth
+func makeAnimationFrame[V any](caption string, root *node[V, struct{}], marks map[string]string) animationFrame {
+	frame := animationFrame{Caption: caption, Nodes: []animationNode{}, Edges: [][2]string{}}
+	column := 0
+	var place func(n *node[V, struct{}], depth int)
+	place = func(n *node[V, struct{}], depth int) {
+		if n == nil {
+			return
+		}
+		place(n.left, depth+1)
+		key := show(n.val)
+		frame.Nodes = append(frame.Nodes, animationNode{
+			Key:  key,
+			X:    float64(column)*48 + 32,
+			Y:    float64(depth)*64 + 32,
+			Red:  n.color == Red,
+			Mark: marks[key],
+		})
+		column++
+		for _, c := range []*node[V, struct{}]{n.left, n.right} {
+			if c != nil {
+				frame.Edges = append(frame.Edges, [2]string{key, show(c.val)})
+			}
+		}
+		place(n.right, depth+1)
+	}
+	place(root, 0)
+	return frame
+}
+
+var animationTemplate = template.Must(template.New("animation").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Red-Black Tree</title>
+<style>
+body { font-family: sans-serif; margin: 1em; }
+#caption { font-size: 1.2em; min-height: 1.5em; }
+#controls { margin: 0.5em 0; }
+svg { border: 1px solid #ccc; width: 100%; height: 70vh; }
+line { stroke: #555; stroke-width: 2; }
+circle { stroke-width: 4; }
+text { fill: white; font-size: 14px; text-anchor: middle; dominant-baseline: central; pointer-events: none; }
+.legend span { display: inline-block; margin-right: 1em; }
+.swatch { display: inline-block; width: 0.8em; height: 0.8em; border: 3px solid; border-radius: 50%; vertical-align: middle; }
+</style>
+</head>
+<body>
+<div id="caption"></div>
+<div id="controls">
+<button id="first">&#x23EE;</button>
+<button id="prev">&#x23F4;</button>
+<button id="play">&#x25B6;</button>
+<button id="next">&#x23F5;</button>
+<button id="last">&#x23ED;</button>
+<input id="slider" type="range" min="0" value="0">
+<span id="position"></span>
+</div>
+<div class="legend">
+<span><span class="swatch" style="border-color: #f5a623"></span> red-red violation</span>
+<span><span class="swatch" style="border-color: #4a90e2"></span> uncle</span>
+<span><span class="swatch" style="border-color: #2ecc71"></span> rotation pivot</span>
+<span><span class="swatch" style="border-color: #9b59b6"></span> inserted or deleted</span>
+</div>
+<svg id="tree" xmlns="http://www.w3.org/2000/svg"><g id="edges"></g><g id="nodes"></g></svg>
+<script>
+const frames = {{.}};
+const strokes = { violation: "#f5a623", uncle: "#4a90e2", pivot: "#2ecc71", new: "#9b59b6" };
+const svgNS = "http://www.w3.org/2000/svg";
+const svg = document.getElementById("tree");
+const edgeLayer = document.getElementById("edges");
+const nodeLayer = document.getElementById("nodes");
+const slider = document.getElementById("slider");
+const elements = new Map();
+let positions = new Map();
+let current = 0;
+let timer = null;
+let transition = 0;
+slider.max = Math.max(0, frames.length - 1);
+
+function element(key) {
+	let g = elements.get(key);
+	if (!g) {
+		g = document.createElementNS(svgNS, "g");
+		const circle = document.createElementNS(svgNS, "circle");
+		circle.setAttribute("r", 18);
+		const text = document.createElementNS(svgNS, "text");
+		text.textContent = key;
+		g.append(circle, text);
+		nodeLayer.append(g);
+		elements.set(key, g);
+	}
+	return g;
+}
+
+function draw(frame, t, from) {
+	const at = new Map();
+	for (const n of frame.nodes) {
+		const start = from.get(n.key) || n;
+		const x = start.x + (n.x - start.x) * t;
+		const y = start.y + (n.y - start.y) * t;
+		at.set(n.key, { x: x, y: y });
+		element(n.key).setAttribute("transform", "translate(" + x + "," + y + ")");
+	}
+	edgeLayer.replaceChildren(...frame.edges.map(([a, b]) => {
+		const line = document.createElementNS(svgNS, "line");
+		line.setAttribute("x1", at.get(a).x);
+		line.setAttribute("y1", at.get(a).y);
+		line.setAttribute("x2", at.get(b).x);
+		line.setAttribute("y2", at.get(b).y);
+		return line;
+	}));
+	return at;
+}
+
+function show(i) {
+	if (frames.length === 0) {
+		document.getElementById("caption").textContent = "No operations";
+		return;
+	}
+	current = Math.max(0, Math.min(frames.length - 1, i));
+	const frame = frames[current];
+	document.getElementById("caption").textContent = frame.caption;
+	document.getElementById("position").textContent = (current + 1) + " / " + frames.length;
+	slider.value = current;
+
+	const keys = new Set(frame.nodes.map(n => n.key));
+	for (const [key, g] of elements) {
+		if (!keys.has(key)) {
+			g.remove();
+			elements.delete(key);
+		}
+	}
+	for (const n of frame.nodes) {
+		const circle = element(n.key).firstChild;
+		circle.setAttribute("fill", n.red ? "#d0021b" : "#222");
+		circle.setAttribute("stroke", strokes[n.mark] || "none");
+	}
+	let width = 64, height = 64;
+	for (const n of frame.nodes) {
+		width = Math.max(width, n.x + 32);
+		height = Math.max(height, n.y + 32);
+	}
+	svg.setAttribute("viewBox", "0 0 " + width + " " + height);
+
+	const from = positions;
+	const started = performance.now();
+	const id = ++transition;
+	function step(now) {
+		if (id !== transition) {
+			return;
+		}
+		const t = Math.min(1, (now - started) / 400);
+		positions = draw(frame, t, from);
+		if (t < 1) {
+			requestAnimationFrame(step);
+		}
+	}
+	requestAnimationFrame(step);
+}
+
+function play() {
+	if (timer) {
+		clearInterval(timer);
+		timer = null;
+		document.getElementById("play").innerHTML = "&#x25B6;";
+		return;
+	}
+	document.getElementById("play").innerHTML = "&#x23F8;";
+	timer = setInterval(() => {
+		if (current >= frames.length - 1) {
+			play();
+		} else {
+			show(current + 1);
+		}
+	}, 1500);
+}
+
+document.getElementById("first").onclick = () => show(0);
+document.getElementById("prev").onclick = () => show(current - 1);
+document.getElementById("next").onclick = () => show(current + 1);
+document.getElementById("last").onclick = () => show(frames.length - 1);
+document.getElementById("play").onclick = play;
+slider.oninput = () => show(Number(slider.value));
+document.onkeydown = e => {
+	if (e.key === "ArrowLeft") show(current - 1);
+	if (e.key === "ArrowRight") show(current + 1);
+	if (e.key === " ") play();
+};
+show(0);
+</script>
+</body>
+</html>
+`))
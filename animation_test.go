@@ -0,0 +1,94 @@
+package redblack
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteAnimationEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAnimation(&buf, []Operation[int]{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "const frames = [];") {
+		t.Fatalf("Expected no frames:\n%s", buf.String())
+	}
+}
+
+func TestWriteAnimationIsSelfContained(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAnimation(&buf, []Operation[int]{Insertion(1), Insertion(2)}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, external := range []string{"<script src", "<link", "@import", "https://"} {
+		if strings.Contains(out, external) {
+			t.Fatalf("Page loads external resources with %s", external)
+		}
+	}
+}
+
+func TestWriteAnimationFrames(t *testing.T) {
+	var buf bytes.Buffer
+	ops := []Operation[int]{Insertion(5), Insertion(3), Insertion(4), Insertion(4), Deletion(3), Deletion(9)}
+	if err := WriteAnimation(&buf, ops); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	captions := []string{
+		`"caption":"Insert 5"`,
+		`"caption":"Scenario 1 at 5: recolor 5"`,
+		`"caption":"Inserted 5"`,
+		`"caption":"Insert 3"`,
+		`"caption":"Insert 4"`,
+		`"caption":"Scenario 3 at 4 (no uncle, triangle): rotate left at 3"`,
+		`"caption":"Scenario 4 at 3 (no uncle, line): recolor 4, 5; rotate right at 5"`,
+		`"caption":"Inserted 4"`,
+		`"caption":"4 is already in the tree"`,
+		`"caption":"Delete 3"`,
+		`"caption":"Deleted 3"`,
+		`"caption":"Delete 9"`,
+	}
+	last := 0
+	for _, caption := range captions {
+		i := strings.Index(out[last:], caption)
+		if i < 0 {
+			t.Fatalf("Missing or misplaced %s", caption)
+		}
+		last += i
+	}
+	if strings.Count(out, `"caption":`) != len(captions) {
+		t.Fatalf("Expected %d frames but got %d", len(captions), strings.Count(out, `"caption":`))
+	}
+}
+
+func TestAnimationMarks(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 8} {
+		tree.Insert(v)
+	}
+	steps := tree.InsertTraced(1)
+
+	marks := animationMarks(steps[1], steps[0].snapshot)
+	expected := map[string]string{"1": markViolation, "3": markViolation, "8": markUncle}
+	if len(marks) != len(expected) {
+		t.Fatalf("Expected %v but got %v", expected, marks)
+	}
+	for k, v := range expected {
+		if marks[k] != v {
+			t.Fatalf("Expected %v but got %v", expected, marks)
+		}
+	}
+}
+
+func TestWriteAnimationEscapesValues(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAnimation(&buf, []Operation[string]{Insertion("</script><b>")}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "</script><b>") {
+		t.Fatalf("Value was not escaped")
+	}
+}
@@ -0,0 +1,733 @@
+package redblack
+
+import (
+	"cmp"
+	"encoding/json"
+	"iter"
+	"slices"
+)
+
+// A Red-Black Tree that stores its nodes in a single slice.
+//
+// The nodes refer to each other by their index in the slice instead
+// of pointers and the color is kept in the top bit of the parent
+// index. If the values don't contain pointers, the garbage collector
+// sees a single pointer-free slice, no matter how big the tree is.
+// Deleted nodes are put on a free list and reused by later inserts.
+//
+// An ArenaTree offers the core API of [Tree]: inserting, deleting,
+// lookups, order statistics, iteration, JSON and validation. The
+// rest, like the debug output, observers, tracing and functions
+// such as [Union] or [FromSorted], is only available for Tree. Copying an ArenaTree value shares the slice,
+// see [ArenaTree.Clone].
+type ArenaTree[V Value] struct {
+	// index 0 is the nil node, which is black and has size 0
+	nodes []anode[V]
+	root  uint32
+	// the first free node, the others are linked through left
+	free uint32
+}
+
+type anode[V any] struct {
+	val   V
+	left  uint32
+	right uint32
+	// the index of the parent with the color in the top bit
+	parent uint32
+	size   uint32
+}
+
+const (
+	arenaNil    uint32 = 0
+	arenaRedBit uint32 = 1 << 31
+	// the top bit of the parent is used for the color
+	arenaMaxNodes = 1<<31 - 1
+)
+
+// MakeArenaTree creates a new Red-Black Tree backed by a slice
+func MakeArenaTree[V Value]() ArenaTree[V] {
+	return ArenaTree[V]{}
+}
+
+// Makes room for n more values without growing the slice
+func (t *ArenaTree[V]) Grow(n int) {
+	if t.nodes == nil {
+		t.nodes = make([]anode[V], 1, n+1)
+		return
+	}
+	t.nodes = slices.Grow(t.nodes, n)
+}
+
+// Insert a value into a the tree.
+//
+// If the value already exists, nothing happens
+func (t *ArenaTree[V]) Insert(v V) {
+	pos := t.seek(v)
+	if pos.match != arenaNil {
+		return
+	}
+
+	n := t.alloc(v, pos.parent)
+	if pos.parent == arenaNil {
+		t.root = n
+	} else if pos.less {
+		t.nodes[pos.parent].left = n
+	} else {
+		t.nodes[pos.parent].right = n
+	}
+	t.resizeAncestors(n, 1)
+	t.fixViolations(n)
+
+	t.setColor(t.root, Black)
+}
+
+// Delete a value from the tree.
+//
+// Returns whether the value was in the tree
+func (t *ArenaTree[V]) Delete(v V) bool {
+	pos := t.seek(v)
+	if pos.match == arenaNil {
+		return false
+	}
+	t.remove(pos.match)
+	return true
+}
+
+// Checks whether the specified value is in the tree
+func (t ArenaTree[V]) Contains(v V) bool {
+	return t.seek(v).match != arenaNil
+}
+
+// Returns the total number of values in the tree
+func (t ArenaTree[V]) Size() int {
+	return t.size(t.root)
+}
+
+// Returns the height of the tree, see [Tree.Height]
+func (t ArenaTree[V]) Height() int {
+	return t.height(t.root)
+}
+
+// Returns the smallest value in the tree
+func (t ArenaTree[V]) Min() (V, bool) {
+	return t.value(t.min(t.root))
+}
+
+// Returns the biggest value in the tree
+func (t ArenaTree[V]) Max() (V, bool) {
+	return t.value(t.max(t.root))
+}
+
+// Removes the smallest value from the tree and returns it
+func (t *ArenaTree[V]) PopMin() (V, bool) {
+	return t.pop(t.min(t.root))
+}
+
+// Removes the biggest value from the tree and returns it
+func (t *ArenaTree[V]) PopMax() (V, bool) {
+	return t.pop(t.max(t.root))
+}
+
+// Returns the largest value that is smaller than or equal to v
+func (t ArenaTree[V]) Floor(v V) (V, bool) {
+	return t.value(t.floor(t.seek(v)))
+}
+
+// Returns the smallest value that is bigger than or equal to v
+func (t ArenaTree[V]) Ceiling(v V) (V, bool) {
+	return t.value(t.ceiling(t.seek(v)))
+}
+
+// Returns the largest value that is strictly smaller than v
+func (t ArenaTree[V]) Lower(v V) (V, bool) {
+	return t.value(t.lower(t.seek(v)))
+}
+
+// Returns the smallest value that is strictly bigger than v
+func (t ArenaTree[V]) Higher(v V) (V, bool) {
+	return t.value(t.higher(t.seek(v)))
+}
+
+// Returns the number of values in the tree that are smaller than v
+func (t ArenaTree[V]) Rank(v V) int {
+	return t.index(t.ceiling(t.seek(v)))
+}
+
+// Returns the k-th smallest value in the tree (zero-based)
+func (t ArenaTree[V]) Select(k int) (V, bool) {
+	if k < 0 || k >= t.Size() {
+		return t.value(arenaNil)
+	}
+	n := t.root
+	for {
+		left := t.size(t.nodes[n].left)
+		if k < left {
+			n = t.nodes[n].left
+		} else if k > left {
+			k -= left + 1
+			n = t.nodes[n].right
+		} else {
+			return t.value(n)
+		}
+	}
+}
+
+// Returns an iterator over all values in ascending order.
+//
+// The tree is read when the loop starts, see [Tree.All].
+func (t *ArenaTree[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		t.between(t.min(t.root), t.Size())(yield)
+	}
+}
+
+// Returns an iterator over all values in descending order.
+//
+// The tree is read when the loop starts, see [Tree.All].
+func (t *ArenaTree[V]) Backward() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := t.max(t.root); n != arenaNil; n = t.prev(n) {
+			if !yield(t.nodes[n].val) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over all values between lo and hi in ascending order.
+//
+// The bounds are looked up when the loop starts, see [Tree.Range].
+func (t *ArenaTree[V]) Range(lo, hi Bound[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		start := t.lowerEnd(lo)
+		t.between(start, t.countBetween(start, t.upperEnd(hi)))(yield)
+	}
+}
+
+// Returns the number of values between lo and hi in O(log n)
+func (t ArenaTree[V]) RangeCount(lo, hi Bound[V]) int {
+	return t.countBetween(t.lowerEnd(lo), t.upperEnd(hi))
+}
+
+// Returns a copy of the tree that does not share any nodes
+//
+// Since all nodes are in one slice, this is a single copy.
+func (t ArenaTree[V]) Clone() ArenaTree[V] {
+	return ArenaTree[V]{nodes: slices.Clone(t.nodes), root: t.root, free: t.free}
+}
+
+// Formats the string in a human readable format, see [Tree.String]
+func (t ArenaTree[V]) String() string {
+	if t.root == arenaNil {
+		return "EmptyTree"
+	}
+	return t.string(t.root)
+}
+
+// Encodes the tree as a JSON array of the values in ascending order.
+//
+// This implements [json.Marshaler].
+func (t ArenaTree[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(slices.AppendSeq(make([]V, 0, t.Size()), t.All()))
+}
+
+// Decodes a JSON array of values into the tree.
+//
+// This implements [json.Unmarshaler]. Any values that were in
+// the tree before are removed.
+func (t *ArenaTree[V]) UnmarshalJSON(data []byte) error {
+	var values []V
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	var tree ArenaTree[V]
+	tree.Grow(len(values))
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	*t = tree
+	return nil
+}
+
+// Checks that the tree is a valid Red-Black Tree, see [Tree.Validate]
+func (t ArenaTree[V]) Validate() error {
+	return validateNodes[V](arenaNodes[V]{t}, t.root, cmp.Compare[V])
+}
+
+// The position of a value in an ArenaTree, see [position]
+type apos struct {
+	match  uint32
+	parent uint32
+	less   bool
+}
+
+func (t ArenaTree[V]) seek(v V) apos {
+	var pos apos
+	for n := t.root; n != arenaNil; {
+		val := t.nodes[n].val
+		if v == val {
+			pos.match = n
+			return pos
+		}
+		pos.parent = n
+		pos.less = v < val
+		if pos.less {
+			n = t.nodes[n].left
+		} else {
+			n = t.nodes[n].right
+		}
+	}
+	return pos
+}
+
+func (t ArenaTree[V]) floor(pos apos) uint32 {
+	if pos.match != arenaNil {
+		return pos.match
+	}
+	return t.before(pos)
+}
+
+func (t ArenaTree[V]) ceiling(pos apos) uint32 {
+	if pos.match != arenaNil {
+		return pos.match
+	}
+	return t.after(pos)
+}
+
+func (t ArenaTree[V]) lower(pos apos) uint32 {
+	if pos.match != arenaNil {
+		return t.prev(pos.match)
+	}
+	return t.before(pos)
+}
+
+func (t ArenaTree[V]) higher(pos apos) uint32 {
+	if pos.match != arenaNil {
+		return t.next(pos.match)
+	}
+	return t.after(pos)
+}
+
+func (t ArenaTree[V]) before(pos apos) uint32 {
+	if pos.parent == arenaNil || !pos.less {
+		return pos.parent
+	}
+	return t.prev(pos.parent)
+}
+
+func (t ArenaTree[V]) after(pos apos) uint32 {
+	if pos.parent == arenaNil || pos.less {
+		return pos.parent
+	}
+	return t.next(pos.parent)
+}
+
+// Returns the first node that is admitted by the lower bound
+func (t ArenaTree[V]) lowerEnd(lo Bound[V]) uint32 {
+	switch lo.kind {
+	case inclusive:
+		return t.ceiling(t.seek(lo.val))
+	case exclusive:
+		return t.higher(t.seek(lo.val))
+	default:
+		return t.min(t.root)
+	}
+}
+
+// Returns the first node that is not admitted by the upper bound
+func (t ArenaTree[V]) upperEnd(hi Bound[V]) uint32 {
+	switch hi.kind {
+	case inclusive:
+		return t.higher(t.seek(hi.val))
+	case exclusive:
+		return t.ceiling(t.seek(hi.val))
+	default:
+		return arenaNil
+	}
+}
+
+// Returns an iterator over count values starting at the node start
+func (t ArenaTree[V]) between(start uint32, count int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := start; count > 0; n = t.next(n) {
+			if !yield(t.nodes[n].val) {
+				return
+			}
+			count--
+		}
+	}
+}
+
+// Returns the number of nodes from start up to (excluding) end
+func (t ArenaTree[V]) countBetween(start, end uint32) int {
+	return max(0, t.index(end)-t.index(start))
+}
+
+// Returns the number of nodes in the tree that are smaller than n,
+// see [rbtree.index]
+func (t ArenaTree[V]) index(n uint32) int {
+	if n == arenaNil {
+		return t.Size()
+	}
+
+	i := t.size(t.nodes[n].left)
+	for p := t.parent(n); p != arenaNil; n, p = p, t.parent(p) {
+		if t.nodes[p].right == n {
+			i += t.size(t.nodes[p].left) + 1
+		}
+	}
+	return i
+}
+
+func (t ArenaTree[V]) value(n uint32) (V, bool) {
+	if n == arenaNil {
+		var zero V
+		return zero, false
+	}
+	return t.nodes[n].val, true
+}
+
+// Takes a node from the free list or appends a new one
+func (t *ArenaTree[V]) alloc(v V, parent uint32) uint32 {
+	if t.nodes == nil {
+		t.nodes = make([]anode[V], 1)
+	}
+
+	n := anode[V]{val: v, parent: parent | arenaRedBit, size: 1}
+	if t.free != arenaNil {
+		i := t.free
+		t.free = t.nodes[i].left
+		t.nodes[i] = n
+		return i
+	}
+
+	if len(t.nodes) > arenaMaxNodes {
+		panic("Can't store more values in an ArenaTree")
+	}
+	t.nodes = append(t.nodes, n)
+	return uint32(len(t.nodes) - 1)
+}
+
+// Puts the node on the free list. The value is cleared, so that it
+// does not keep anything alive.
+func (t *ArenaTree[V]) release(n uint32) {
+	t.nodes[n] = anode[V]{left: t.free}
+	t.free = n
+}
+
+func (t ArenaTree[V]) parent(n uint32) uint32 {
+	return t.nodes[n].parent &^ arenaRedBit
+}
+
+func (t *ArenaTree[V]) setParent(n uint32, p uint32) {
+	t.nodes[n].parent = t.nodes[n].parent&arenaRedBit | p
+}
+
+// leaves are black, so the nil node is black
+func (t ArenaTree[V]) color(n uint32) Color {
+	if n == arenaNil || t.nodes[n].parent&arenaRedBit == 0 {
+		return Black
+	}
+	return Red
+}
+
+func (t *ArenaTree[V]) setColor(n uint32, c Color) {
+	if c == Red {
+		t.nodes[n].parent |= arenaRedBit
+	} else {
+		t.nodes[n].parent &^= arenaRedBit
+	}
+}
+
+func (t ArenaTree[V]) size(n uint32) int {
+	if n == arenaNil {
+		return 0
+	}
+	return int(t.nodes[n].size)
+}
+
+func (t *ArenaTree[V]) updateSize(n uint32) {
+	t.nodes[n].size = uint32(t.size(t.nodes[n].left) + t.size(t.nodes[n].right) + 1)
+}
+
+// Adds delta to the size of all ancestors of n
+func (t *ArenaTree[V]) resizeAncestors(n uint32, delta int) {
+	for p := t.parent(n); p != arenaNil; p = t.parent(p) {
+		t.nodes[p].size = uint32(int(t.nodes[p].size) + delta)
+	}
+}
+
+func (t ArenaTree[V]) min(n uint32) uint32 {
+	if n == arenaNil {
+		return n
+	}
+	for t.nodes[n].left != arenaNil {
+		n = t.nodes[n].left
+	}
+	return n
+}
+
+func (t ArenaTree[V]) max(n uint32) uint32 {
+	if n == arenaNil {
+		return n
+	}
+	for t.nodes[n].right != arenaNil {
+		n = t.nodes[n].right
+	}
+	return n
+}
+
+func (t ArenaTree[V]) next(n uint32) uint32 {
+	if right := t.nodes[n].right; right != arenaNil {
+		return t.min(right)
+	}
+	p := t.parent(n)
+	for p != arenaNil && t.nodes[p].right == n {
+		n, p = p, t.parent(p)
+	}
+	return p
+}
+
+func (t ArenaTree[V]) prev(n uint32) uint32 {
+	if left := t.nodes[n].left; left != arenaNil {
+		return t.max(left)
+	}
+	p := t.parent(n)
+	for p != arenaNil && t.nodes[p].left == n {
+		n, p = p, t.parent(p)
+	}
+	return p
+}
+
+func (t ArenaTree[V]) height(n uint32) int {
+	if n == arenaNil {
+		return 0
+	}
+	return max(t.height(t.nodes[n].left), t.height(t.nodes[n].right)) + 1
+}
+
+func (t ArenaTree[V]) string(n uint32) string {
+	if n == arenaNil {
+		return ""
+	}
+	return "L = {" + t.string(t.nodes[n].left) + "} " + show(t.nodes[n].val) + " R = {" + t.string(t.nodes[n].right) + "}"
+}
+
+func (t *ArenaTree[V]) pop(n uint32) (V, bool) {
+	v, ok := t.value(n)
+	if ok {
+		t.remove(n)
+	}
+	return v, ok
+}
+
+// Removes the node from the tree and restores the properties,
+// see [rbtree.remove]
+func (t *ArenaTree[V]) remove(n uint32) {
+	if t.nodes[n].left != arenaNil && t.nodes[n].right != arenaNil {
+		// nodes are not handed out, so the value can be moved
+		successor := t.min(t.nodes[n].right)
+		t.nodes[n].val = t.nodes[successor].val
+		n = successor
+	}
+
+	t.resizeAncestors(n, -1)
+	t.nodes[n].size = 0
+
+	child := t.nodes[n].left
+	if child == arenaNil {
+		child = t.nodes[n].right
+	}
+
+	if child != arenaNil {
+		t.setColor(child, Black)
+		t.replace(n, child)
+	} else if t.parent(n) == arenaNil {
+		t.root = arenaNil
+	} else {
+		if t.color(n) == Black {
+			t.fixDoubleBlack(n)
+		}
+		t.replace(n, arenaNil)
+	}
+	t.release(n)
+}
+
+// replace n with the specified child (which may be nil) in n's parent
+func (t *ArenaTree[V]) replace(n uint32, child uint32) {
+	p := t.parent(n)
+	if child != arenaNil {
+		t.setParent(child, p)
+	}
+	if p == arenaNil {
+		t.root = child
+	} else if t.nodes[p].left == n {
+		t.nodes[p].left = child
+	} else {
+		t.nodes[p].right = child
+	}
+}
+
+// Restores the properties after n has been inserted as a red node,
+// see [rbtree.fixViolations]
+func (t *ArenaTree[V]) fixViolations(n uint32) {
+	for {
+		p := t.parent(n)
+		if p == arenaNil || t.color(p) != Red {
+			return
+		}
+		g := t.parent(p)
+		if g == arenaNil {
+			return
+		}
+
+		parentIsLeft := t.nodes[g].left == p
+		uncle := t.nodes[g].left
+		if parentIsLeft {
+			uncle = t.nodes[g].right
+		}
+
+		if t.color(uncle) == Red {
+			// scenario 2
+			t.setColor(p, Black)
+			t.setColor(uncle, Black)
+			t.setColor(g, Red)
+			n = g
+			continue
+		}
+
+		if (t.nodes[p].left == n) != parentIsLeft {
+			// scenario 3: turn the triangle into a line
+			if parentIsLeft {
+				t.leftRotate(p)
+			} else {
+				t.rightRotate(p)
+			}
+			n, p = p, n
+		}
+
+		// scenario 4
+		t.setColor(p, Black)
+		t.setColor(g, Red)
+		if parentIsLeft {
+			t.rightRotate(g)
+		} else {
+			t.leftRotate(g)
+		}
+		return
+	}
+}
+
+// Fixes the missing black node on the path through n,
+// see [rbtree.fixDoubleBlack]
+func (t *ArenaTree[V]) fixDoubleBlack(n uint32) {
+	for t.parent(n) != arenaNil && t.color(n) == Black {
+		p := t.parent(n)
+		if t.nodes[p].left == n {
+			sibling := t.nodes[p].right
+			if t.color(sibling) == Red {
+				// case 1
+				t.setColor(sibling, Black)
+				t.setColor(p, Red)
+				t.leftRotate(p)
+				continue
+			}
+			if t.color(t.nodes[sibling].left) == Black && t.color(t.nodes[sibling].right) == Black {
+				// case 2
+				t.setColor(sibling, Red)
+				n = p
+				continue
+			}
+			if t.color(t.nodes[sibling].right) == Black {
+				// case 3
+				t.setColor(t.nodes[sibling].left, Black)
+				t.setColor(sibling, Red)
+				t.rightRotate(sibling)
+				sibling = t.nodes[p].right
+			}
+			// case 4
+			t.setColor(sibling, t.color(p))
+			t.setColor(p, Black)
+			t.setColor(t.nodes[sibling].right, Black)
+			t.leftRotate(p)
+			return
+		} else {
+			sibling := t.nodes[p].left
+			if t.color(sibling) == Red {
+				// case 1
+				t.setColor(sibling, Black)
+				t.setColor(p, Red)
+				t.rightRotate(p)
+				continue
+			}
+			if t.color(t.nodes[sibling].left) == Black && t.color(t.nodes[sibling].right) == Black {
+				// case 2
+				t.setColor(sibling, Red)
+				n = p
+				continue
+			}
+			if t.color(t.nodes[sibling].left) == Black {
+				// case 3
+				t.setColor(t.nodes[sibling].right, Black)
+				t.setColor(sibling, Red)
+				t.leftRotate(sibling)
+				sibling = t.nodes[p].left
+			}
+			// case 4
+			t.setColor(sibling, t.color(p))
+			t.setColor(p, Black)
+			t.setColor(t.nodes[sibling].left, Black)
+			t.rightRotate(p)
+			return
+		}
+	}
+	t.setColor(n, Black)
+}
+
+func (t *ArenaTree[V]) leftRotate(n uint32) {
+	r := t.nodes[n].right
+	if r == arenaNil {
+		panic("Can't left-rotate if I don't have a right child")
+	}
+
+	inner := t.nodes[r].left
+	t.nodes[n].right = inner
+	if inner != arenaNil {
+		t.setParent(inner, n)
+	}
+	t.replace(n, r)
+	t.nodes[r].left = n
+	t.setParent(n, r)
+
+	t.nodes[r].size = t.nodes[n].size
+	t.updateSize(n)
+}
+
+func (t *ArenaTree[V]) rightRotate(n uint32) {
+	l := t.nodes[n].left
+	if l == arenaNil {
+		panic("Can't right-rotate if I don't have a left child")
+	}
+
+	inner := t.nodes[l].right
+	t.nodes[n].left = inner
+	if inner != arenaNil {
+		t.setParent(inner, n)
+	}
+	t.replace(n, l)
+	t.nodes[l].right = n
+	t.setParent(n, l)
+
+	t.nodes[l].size = t.nodes[n].size
+	t.updateSize(n)
+}
+
+// The nodes of an ArenaTree as the validator sees them
+type arenaNodes[V Value] struct {
+	ArenaTree[V]
+}
+
+func (a arenaNodes[V]) val(n uint32) V        { return a.nodes[n].val }
+func (a arenaNodes[V]) left(n uint32) uint32  { return a.nodes[n].left }
+func (a arenaNodes[V]) right(n uint32) uint32 { return a.nodes[n].right }
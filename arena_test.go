@@ -0,0 +1,237 @@
+package redblack
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestArenaEmpty(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	if tree.Contains(5) || tree.Size() != 0 || tree.Height() != 0 {
+		t.Fatalf("Empty tree is not empty")
+	}
+	if _, ok := tree.Min(); ok {
+		t.Fatalf("Empty tree has a minimum")
+	}
+	if tree.Delete(5) {
+		t.Fatalf("Deleted 5 from empty tree")
+	}
+	if tree.String() != "EmptyTree" {
+		t.Fatalf("Unexpected string %s", tree.String())
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArenaMatchesTree(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	arena := MakeArenaTree[int]()
+	tree := MakeTree[int]()
+
+	for i := range 5000 {
+		v := r.IntN(500)
+		if r.IntN(3) == 0 {
+			if arena.Delete(v) != tree.Delete(v) {
+				t.Fatalf("Delete of %d differs", v)
+			}
+		} else {
+			arena.Insert(v)
+			tree.Insert(v)
+		}
+		if i%100 == 0 {
+			if err := arena.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if err := arena.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if arena.String() != tree.String() {
+		t.Fatalf("Trees have a different shape:\n%s\n%s", arena.String(), tree.String())
+	}
+	if !slices.Equal(slices.Collect(arena.All()), slices.Collect(tree.All())) {
+		t.Fatalf("Trees have different values")
+	}
+	if !slices.Equal(slices.Collect(arena.Backward()), slices.Collect(tree.Backward())) {
+		t.Fatalf("Trees have different values backwards")
+	}
+
+	for v := -1; v <= 501; v++ {
+		check := func(name string, a func(int) (int, bool), b func(int) (int, bool)) {
+			av, aok := a(v)
+			bv, bok := b(v)
+			if av != bv || aok != bok {
+				t.Fatalf("%s(%d) is %d, %t but should be %d, %t", name, v, av, aok, bv, bok)
+			}
+		}
+		check("Floor", arena.Floor, tree.Floor)
+		check("Ceiling", arena.Ceiling, tree.Ceiling)
+		check("Lower", arena.Lower, tree.Lower)
+		check("Higher", arena.Higher, tree.Higher)
+		check("Select", arena.Select, tree.Select)
+		if arena.Rank(v) != tree.Rank(v) {
+			t.Fatalf("Rank of %d differs", v)
+		}
+		if arena.RangeCount(Exclusive(v), Inclusive(v+50)) != tree.RangeCount(Exclusive(v), Inclusive(v+50)) {
+			t.Fatalf("RangeCount from %d differs", v)
+		}
+	}
+
+	got := slices.Collect(arena.Range(Inclusive(100), Exclusive(200)))
+	expected := slices.Collect(tree.Range(Inclusive(100), Exclusive(200)))
+	if !slices.Equal(got, expected) {
+		t.Fatalf("Expected range %v but got %v", expected, got)
+	}
+}
+
+func TestArenaReusesFreeNodes(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	for i := range 100 {
+		tree.Insert(i)
+	}
+	allocated := len(tree.nodes)
+
+	for i := range 50 {
+		tree.Delete(i * 2)
+	}
+	for i := range 50 {
+		tree.Insert(1000 + i)
+	}
+
+	if len(tree.nodes) != allocated {
+		t.Fatalf("Expected %d nodes but got %d", allocated, len(tree.nodes))
+	}
+	if tree.Size() != 100 {
+		t.Fatalf("Tree does not have size 100")
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArenaColorBit(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	tree.Insert(5)
+	tree.Insert(1)
+
+	one := tree.seek(1).match
+	if tree.color(one) != Red || tree.parent(one) != tree.root {
+		t.Fatalf("Red node does not keep its parent")
+	}
+	tree.setColor(one, Black)
+	if tree.color(one) != Black || tree.parent(one) != tree.root {
+		t.Fatalf("Black node does not keep its parent")
+	}
+}
+
+func TestArenaPop(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	for _, v := range []int{5, 3, 8, 1} {
+		tree.Insert(v)
+	}
+	if v, ok := tree.PopMin(); !ok || v != 1 {
+		t.Fatalf("Expected 1 but got %d", v)
+	}
+	if v, ok := tree.PopMax(); !ok || v != 8 {
+		t.Fatalf("Expected 8 but got %d", v)
+	}
+	if tree.Size() != 2 || tree.Contains(1) || tree.Contains(8) {
+		t.Fatalf("Popped values are still in the tree")
+	}
+}
+
+func TestArenaCloneAndJSON(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	for _, v := range []int{5, 3, 8} {
+		tree.Insert(v)
+	}
+	clone := tree.Clone()
+	clone.Insert(10)
+	if tree.Contains(10) {
+		t.Fatalf("Clone shares nodes with the original")
+	}
+
+	data, err := json.Marshal(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[3,5,8,10]" {
+		t.Fatalf("Unexpected JSON %s", data)
+	}
+
+	var decoded ArenaTree[int]
+	if err := json.Unmarshal([]byte("[4, 2, 4, 9]"), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Collect(decoded.All()), []int{2, 4, 9}) {
+		t.Fatalf("Unexpected values %v", slices.Collect(decoded.All()))
+	}
+}
+
+func TestArenaValidateReportsViolations(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	for _, v := range []int{5, 3, 8} {
+		tree.Insert(v)
+	}
+	tree.setColor(tree.root, Red)
+
+	verr := validationErrorOf(t, tree.Validate())
+	if v := verr.Violations[0]; v.Kind != RedRoot || v.Value != 5 {
+		t.Fatalf("Unexpected violation %s", v)
+	}
+}
+
+func BenchmarkArenaInsertRandom(b *testing.B) {
+	values := benchmarkValues(10000)
+	b.ReportAllocs()
+	for range b.N {
+		tree := MakeArenaTree[int]()
+		for _, v := range values {
+			tree.Insert(v)
+		}
+	}
+}
+
+func TestArenaRangeLooksUpBoundsWhenLoopStarts(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	for i := range 9 {
+		tree.Insert(i)
+	}
+	r := tree.Range(Inclusive(2), Inclusive(5))
+	for i := 10; i < 20; i++ {
+		tree.Insert(i)
+	}
+	tree.Delete(2)
+
+	values := slices.Collect(r)
+	expected := []int{3, 4, 5}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("Expected %v but got %v", expected, values)
+	}
+}
+
+func TestArenaAllReadsTreeWhenLoopStarts(t *testing.T) {
+	tree := MakeArenaTree[int]()
+	for i := 100; i > 90; i-- {
+		tree.Insert(i)
+	}
+	all, backward := tree.All(), tree.Backward()
+	// the nodes slice grows and the root moves
+	for i := range 90 {
+		tree.Insert(i)
+	}
+
+	values := slices.Collect(all)
+	if len(values) != 100 || !slices.IsSorted(values) {
+		t.Fatalf("Expected 100 sorted values but got %v", values)
+	}
+	values = slices.Collect(backward)
+	if len(values) != 100 || values[0] != 100 {
+		t.Fatalf("Expected 100 values from 100 down but got %v", values)
+	}
+}
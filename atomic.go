@@ -0,0 +1,110 @@
+package redblack
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// A Red-Black Tree with lock-free reads
+//
+// Every modification creates a new version of a [PersistentTree],
+// which is then published atomically. Readers never block and
+// always see a consistent version, even while a writer is busy.
+// Writers are serialized with a mutex. The zero value is an empty
+// tree ready to use. An AtomicTree must not be copied after first use.
+type AtomicTree[V Value] struct {
+	mu   sync.Mutex // held by writers
+	root atomic.Pointer[pnode[V]]
+}
+
+// MakeAtomicTree creates a new Red-Black Tree with lock-free reads
+func MakeAtomicTree[V Value]() *AtomicTree[V] {
+	return &AtomicTree[V]{}
+}
+
+// Returns the current version of the tree.
+//
+// The version is immutable, so any number of operations on it
+// see the same values.
+func (t *AtomicTree[V]) Load() PersistentTree[V] {
+	return PersistentTree[V]{t.root.Load()}
+}
+
+// Replaces the current version with the result of f.
+//
+// The function receives the current version and is called with
+// the writer lock held, so several modifications can be published
+// as one new version.
+func (t *AtomicTree[V]) Update(f func(PersistentTree[V]) PersistentTree[V]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root.Store(f(t.Load()).root)
+}
+
+// Insert a value into a the tree.
+//
+// If the value already exists, nothing happens
+func (t *AtomicTree[V]) Insert(v V) {
+	t.Update(func(tree PersistentTree[V]) PersistentTree[V] {
+		return tree.Insert(v)
+	})
+}
+
+// Delete a value from the tree.
+//
+// Returns whether the value was in the tree
+func (t *AtomicTree[V]) Delete(v V) bool {
+	deleted := false
+	t.Update(func(tree PersistentTree[V]) PersistentTree[V] {
+		next := tree.Delete(v)
+		deleted = next.root != tree.root
+		return next
+	})
+	return deleted
+}
+
+// Checks whether the specified value is in the tree
+func (t *AtomicTree[V]) Contains(v V) bool {
+	return t.Load().Contains(v)
+}
+
+// Returns the total number of nodes in the tree
+func (t *AtomicTree[V]) Size() int {
+	return t.Load().Size()
+}
+
+// Returns the smallest value in the tree
+func (t *AtomicTree[V]) Min() (V, bool) {
+	return t.Load().Min()
+}
+
+// Returns the biggest value in the tree
+func (t *AtomicTree[V]) Max() (V, bool) {
+	return t.Load().Max()
+}
+
+// Returns the largest value that is smaller than or equal to v
+func (t *AtomicTree[V]) Floor(v V) (V, bool) {
+	return t.Load().Floor(v)
+}
+
+// Returns the smallest value that is bigger than or equal to v
+func (t *AtomicTree[V]) Ceiling(v V) (V, bool) {
+	return t.Load().Ceiling(v)
+}
+
+// Returns an iterator over all values in ascending order.
+//
+// The iterator walks the version that is current when the loop
+// starts. It does not block writers and is not affected by them,
+// so the loop body may modify the tree.
+func (t *AtomicTree[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range t.Load().All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package redblack
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicTreeZeroValue(t *testing.T) {
+	var tree AtomicTree[int]
+	if tree.Contains(1) || tree.Size() != 0 {
+		t.Fatalf("Zero value is not empty")
+	}
+	tree.Insert(1)
+	if !tree.Contains(1) {
+		t.Fatalf("Tree does not contain 1")
+	}
+	if !tree.Delete(1) {
+		t.Fatalf("Did not delete 1")
+	}
+	if tree.Delete(1) {
+		t.Fatalf("Deleted 1 twice")
+	}
+}
+
+func TestAtomicTreeLoadIsSnapshot(t *testing.T) {
+	tree := MakeAtomicTree[int]()
+	for i := range 10 {
+		tree.Insert(i)
+	}
+
+	version := tree.Load()
+	for v := range tree.All() {
+		tree.Delete(v)
+	}
+
+	if tree.Size() != 0 {
+		t.Fatalf("Tree is not empty")
+	}
+	if version.Size() != 10 {
+		t.Fatalf("Loaded version was modified")
+	}
+}
+
+func TestAtomicTreeUpdate(t *testing.T) {
+	tree := MakeAtomicTree[int]()
+	tree.Update(func(tree PersistentTree[int]) PersistentTree[int] {
+		for i := range 100 {
+			tree = tree.Insert(i)
+		}
+		return tree
+	})
+	if tree.Size() != 100 {
+		t.Fatalf("Expected size 100 but got %d", tree.Size())
+	}
+	validatePersistentTree(t, tree.Load())
+}
+
+func TestAtomicTreeConcurrentReadersAndWriters(t *testing.T) {
+	tree := MakeAtomicTree[int]()
+	for i := range 1000 {
+		tree.Insert(i * 2)
+	}
+
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				tree.Insert(g*1000 + i*2 + 1)
+				tree.Delete(g*1000 + i*2 + 1)
+			}
+		}()
+	}
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				if !tree.Contains(i * 2) {
+					t.Errorf("Tree lost %d", i*2)
+					return
+				}
+				if v, ok := tree.Floor(i*2 + 1); !ok || v < i*2 {
+					t.Errorf("Unexpected floor %d for %d", v, i*2+1)
+					return
+				}
+			}
+			prev := -1
+			for v := range tree.All() {
+				if v <= prev {
+					t.Errorf("Iteration is not ordered: %d after %d", v, prev)
+					return
+				}
+				prev = v
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tree.Size() != 1000 {
+		t.Fatalf("Expected size 1000 but got %d", tree.Size())
+	}
+	validatePersistentTree(t, tree.Load())
+}
@@ -0,0 +1,184 @@
+package redblack
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The nodes of a tree as the text exporters see them.
+//
+// JSON, DOT, TikZ and Mermaid are all written from a diagram, so
+// they number the nodes in the same (pre-)order and label them
+// with the same function.
+type diagram[V any, D any] struct {
+	root  *diagramNode[V, D]
+	nodes map[*node[V, D]]*diagramNode[V, D]
+	label func(V) (string, error)
+}
+
+type diagramNode[V any, D any] struct {
+	node  *node[V, D]
+	id    string // n0 for the root, then in pre-order
+	label string
+	left  *diagramNode[V, D]
+	right *diagramNode[V, D]
+}
+
+// Formats the value like everywhere else in the package
+func showLabel[V any](v V) (string, error) {
+	return show(v), nil
+}
+
+func makeDiagram[V any, D any](root *node[V, D], label func(V) (string, error)) (diagram[V, D], error) {
+	d := diagram[V, D]{nodes: map[*node[V, D]]*diagramNode[V, D]{}, label: label}
+	var err error
+	d.root, err = d.add(root)
+	return d, err
+}
+
+func (d *diagram[V, D]) add(n *node[V, D]) (*diagramNode[V, D], error) {
+	if n == nil {
+		return nil, nil
+	}
+
+	label, err := d.label(n.val)
+	if err != nil {
+		return nil, err
+	}
+	dn := &diagramNode[V, D]{node: n, id: fmt.Sprintf("n%d", len(d.nodes)), label: label}
+	d.nodes[n] = dn
+
+	if dn.left, err = d.add(n.left); err != nil {
+		return nil, err
+	}
+	if dn.right, err = d.add(n.right); err != nil {
+		return nil, err
+	}
+	return dn, nil
+}
+
+// Returns the label of the node the parent reference of n points to,
+// which does not need to be in the tree
+func (d *diagram[V, D]) parentLabel(n *diagramNode[V, D]) (string, bool, error) {
+	if n.node.p == nil {
+		return "", false, nil
+	}
+	if p, ok := d.nodes[n.node.p]; ok {
+		return p.label, true, nil
+	}
+	label, err := d.label(n.node.p.val)
+	return label, true, err
+}
+
+// Visits the nodes in the order of their ids
+func (n *diagramNode[V, D]) walk(visit func(n *diagramNode[V, D])) {
+	if n == nil {
+		return
+	}
+	visit(n)
+	n.left.walk(visit)
+	n.right.walk(visit)
+}
+
+var tikzEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`#`, `\#`,
+	`$`, `\$`,
+	`%`, `\%`,
+	`&`, `\&`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// Writes the tree as a LaTeX forest environment.
+//
+// The document needs \usepackage{forest}. The nodes are filled red
+// or black, and an only child keeps its side thanks to an invisible
+// sibling.
+func (t Tree[V]) WriteTikZ(w io.Writer) error {
+	d, err := makeDiagram(t.node, showLabel[V])
+	if err != nil {
+		return err
+	}
+
+	var acc strings.Builder
+	acc.WriteString("\\begin{forest}\n")
+	acc.WriteString("for tree={circle, draw, text=white, minimum size=2em, inner sep=1pt}\n")
+	if d.root == nil {
+		acc.WriteString("[, phantom]\n")
+	} else {
+		d.root.tikz(&acc, "")
+	}
+	acc.WriteString("\\end{forest}\n")
+
+	_, err = io.WriteString(w, acc.String())
+	return err
+}
+
+func (n *diagramNode[V, D]) tikz(acc *strings.Builder, indent string) {
+	fill := "black"
+	if n.node.color == Red {
+		fill = "red"
+	}
+	fmt.Fprintf(acc, "%s[{%s}, fill=%s", indent, tikzEscaper.Replace(n.label), fill)
+	if n.left != nil || n.right != nil {
+		acc.WriteString("\n")
+		for _, c := range []*diagramNode[V, D]{n.left, n.right} {
+			if c == nil {
+				fmt.Fprintf(acc, "%s  [, phantom]\n", indent)
+			} else {
+				c.tikz(acc, indent+"  ")
+			}
+		}
+		acc.WriteString(indent)
+	}
+	acc.WriteString("]\n")
+}
+
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;")
+
+// Writes the tree as a Mermaid flowchart.
+//
+// To embed it in Markdown, put it in a code block with the
+// language mermaid. The nodes are circles in the classes red and
+// black, and the children are linked from left to right.
+func (t Tree[V]) WriteMermaid(w io.Writer) error {
+	d, err := makeDiagram(t.node, showLabel[V])
+	if err != nil {
+		return err
+	}
+
+	var acc strings.Builder
+	var reds, blacks []string
+	acc.WriteString("graph TD\n")
+	d.root.walk(func(n *diagramNode[V, struct{}]) {
+		fmt.Fprintf(&acc, "    %s((\"%s\"))\n", n.id, mermaidEscaper.Replace(n.label))
+		if n.node.color == Red {
+			reds = append(reds, n.id)
+		} else {
+			blacks = append(blacks, n.id)
+		}
+	})
+	d.root.walk(func(n *diagramNode[V, struct{}]) {
+		for _, c := range []*diagramNode[V, struct{}]{n.left, n.right} {
+			if c != nil {
+				fmt.Fprintf(&acc, "    %s --> %s\n", n.id, c.id)
+			}
+		}
+	})
+	acc.WriteString("    classDef black fill:#000,stroke:#000,color:#fff\n")
+	acc.WriteString("    classDef red fill:#d0021b,stroke:#d0021b,color:#fff\n")
+	if len(blacks) > 0 {
+		fmt.Fprintf(&acc, "    class %s black\n", strings.Join(blacks, ","))
+	}
+	if len(reds) > 0 {
+		fmt.Fprintf(&acc, "    class %s red\n", strings.Join(reds, ","))
+	}
+
+	_, err = io.WriteString(w, acc.String())
+	return err
+}
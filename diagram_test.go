@@ -0,0 +1,138 @@
+package redblack
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiagramNumbersInPreOrder(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 8, 1, 4} {
+		tree.Insert(v)
+	}
+
+	d, err := makeDiagram(tree.node, showLabel[int])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var acc []string
+	d.root.walk(func(n *diagramNode[int, struct{}]) {
+		acc = append(acc, n.id+"="+n.label)
+	})
+	expected := "n0=5 n1=3 n2=1 n3=4 n4=8"
+	if strings.Join(acc, " ") != expected {
+		t.Fatalf("Expected %s but got %s", expected, strings.Join(acc, " "))
+	}
+}
+
+func TestWriteTikZ(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 8, 1} {
+		tree.Insert(v)
+	}
+
+	var buf bytes.Buffer
+	if err := tree.WriteTikZ(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `\begin{forest}
+for tree={circle, draw, text=white, minimum size=2em, inner sep=1pt}
+[{5}, fill=black
+  [{3}, fill=black
+    [{1}, fill=red]
+    [, phantom]
+  ]
+  [{8}, fill=black]
+]
+\end{forest}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteTikZEmptyAndEscaped(t *testing.T) {
+	tree := MakeTree[string]()
+	var buf bytes.Buffer
+	if err := tree.WriteTikZ(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "[, phantom]") {
+		t.Fatalf("Empty tree is not a phantom: %s", buf.String())
+	}
+
+	tree.Insert("a_b{%}")
+	buf.Reset()
+	if err := tree.WriteTikZ(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `[{a\_b\{\%\}}, fill=black]`) {
+		t.Fatalf("Label was not escaped: %s", buf.String())
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 8, 1} {
+		tree.Insert(v)
+	}
+
+	var buf bytes.Buffer
+	if err := tree.WriteMermaid(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `graph TD
+    n0(("5"))
+    n1(("3"))
+    n2(("1"))
+    n3(("8"))
+    n0 --> n1
+    n0 --> n3
+    n1 --> n2
+    classDef black fill:#000,stroke:#000,color:#fff
+    classDef red fill:#d0021b,stroke:#d0021b,color:#fff
+    class n0,n1,n3 black
+    class n2 red
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, buf.String())
+	}
+}
+
+func TestExportersShareIds(t *testing.T) {
+	tree := MakeTree[string]()
+	for _, v := range []string{"m", "c", "x", `q"`} {
+		tree.Insert(v)
+	}
+
+	var dot, mermaid bytes.Buffer
+	if err := tree.WriteDOT(&dot, DOTOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.WriteMermaid(&mermaid); err != nil {
+		t.Fatal(err)
+	}
+	for _, edge := range []string{"n0 -> n1", "n0 -> n2", "n2 -> n3"} {
+		if !strings.Contains(dot.String(), edge) {
+			t.Fatalf("DOT is missing %s: %s", edge, dot.String())
+		}
+		if !strings.Contains(mermaid.String(), strings.Replace(edge, "->", "-->", 1)) {
+			t.Fatalf("Mermaid is missing %s: %s", edge, mermaid.String())
+		}
+	}
+	if !strings.Contains(mermaid.String(), `n3(("q#quot;"))`) {
+		t.Fatalf("Quote was not escaped: %s", mermaid.String())
+	}
+}
+
+func TestWriteMermaidWriterError(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(1)
+	if err := tree.WriteMermaid(failingWriter{}); err == nil {
+		t.Fatal("Expected error from writer")
+	}
+	if err := tree.WriteTikZ(failingWriter{}); err == nil {
+		t.Fatal("Expected error from writer")
+	}
+}
@@ -0,0 +1,102 @@
+package redblack
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Options for [Tree.WriteDOT]
+type DOTOptions struct {
+	// Draw the nil leaves as small black boxes
+	NilLeaves bool
+	// Draw an edge from every node back to its parent
+	ParentEdges bool
+}
+
+// Writes the tree as a Graphviz digraph.
+//
+// The nodes are filled red or black according to their color.
+// The output can be rendered with e.g. `dot -Tsvg`.
+func (t Tree[V]) WriteDOT(w io.Writer, opts DOTOptions) error {
+	diagram, err := makeDiagram(t.node, showLabel[V])
+	if err != nil {
+		return err
+	}
+
+	d := dotWriter[V, struct{}]{opts: opts, diagram: diagram}
+	d.line("digraph RedBlackTree {")
+	d.line("\tnode [shape=circle, style=filled, fontcolor=white];")
+	d.node(diagram.root)
+	if opts.ParentEdges {
+		d.parents(diagram.root)
+	}
+	d.line("}")
+
+	_, err = io.WriteString(w, d.acc.String())
+	return err
+}
+
+type dotWriter[V any, D any] struct {
+	opts     DOTOptions
+	acc      strings.Builder
+	diagram  diagram[V, D]
+	leaves   int
+	dangling map[*node[V, D]]string
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func (d *dotWriter[V, D]) line(format string, args ...any) {
+	fmt.Fprintf(&d.acc, format, args...)
+	d.acc.WriteString("\n")
+}
+
+// Writes the node and its subtree and returns the id of the node
+func (d *dotWriter[V, D]) node(n *diagramNode[V, D]) string {
+	if n == nil {
+		if !d.opts.NilLeaves {
+			return ""
+		}
+		id := fmt.Sprintf("nil%d", d.leaves)
+		d.leaves++
+		d.line("\t%s [label=\"\", shape=box, width=0.15, height=0.15, fillcolor=black];", id)
+		return id
+	}
+
+	d.line("\t%s [label=\"%s\", fillcolor=%s];", n.id, dotEscaper.Replace(n.label), n.node.color)
+
+	if left := d.node(n.left); left != "" {
+		d.line("\t%s -> %s;", n.id, left)
+	}
+	if right := d.node(n.right); right != "" {
+		d.line("\t%s -> %s;", n.id, right)
+	}
+	return n.id
+}
+
+// Writes an edge from every node to its parent. A parent which
+// is not part of the tree is drawn as a separate node.
+func (d *dotWriter[V, D]) parents(root *diagramNode[V, D]) {
+	root.walk(func(n *diagramNode[V, D]) {
+		p := n.node.p
+		if p == nil {
+			return
+		}
+
+		var parent string
+		if dn, ok := d.diagram.nodes[p]; ok {
+			parent = dn.id
+		} else if parent, ok = d.dangling[p]; !ok {
+			if d.dangling == nil {
+				d.dangling = map[*node[V, D]]string{}
+			}
+			parent = fmt.Sprintf("dangling%d", len(d.dangling))
+			d.dangling[p] = parent
+			// the labels are formatted with show, which never fails
+			label, _, _ := d.diagram.parentLabel(n)
+			d.line("\t%s [label=\"%s\", shape=doublecircle, fillcolor=gray];", parent, dotEscaper.Replace(label))
+		}
+		d.line("\t%s -> %s [style=dashed, color=gray, constraint=false];", n.id, parent)
+	})
+}
@@ -0,0 +1,115 @@
+package redblack
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOTEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	var buf bytes.Buffer
+	if err := tree.WriteDOT(&buf, DOTOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "digraph RedBlackTree {\n\tnode [shape=circle, style=filled, fontcolor=white];\n}\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected '%s' but got '%s'", expected, buf.String())
+	}
+}
+
+func TestWriteDOTSmallTree(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(1)
+	tree.Insert(7)
+
+	var buf bytes.Buffer
+	if err := tree.WriteDOT(&buf, DOTOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expected := `digraph RedBlackTree {
+	node [shape=circle, style=filled, fontcolor=white];
+	n0 [label="5", fillcolor=black];
+	n1 [label="1", fillcolor=red];
+	n0 -> n1;
+	n2 [label="7", fillcolor=red];
+	n0 -> n2;
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected '%s' but got '%s'", expected, buf.String())
+	}
+}
+
+func TestWriteDOTNilLeaves(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(1)
+
+	var buf bytes.Buffer
+	if err := tree.WriteDOT(&buf, DOTOptions{NilLeaves: true}); err != nil {
+		t.Fatal(err)
+	}
+	if count := strings.Count(buf.String(), "shape=box"); count != 3 {
+		t.Fatalf("Expected 3 nil leaves but got %d", count)
+	}
+	if !strings.Contains(buf.String(), "n0 -> nil2;") {
+		t.Fatalf("Right nil leaf of root is missing: %s", buf.String())
+	}
+}
+
+func TestWriteDOTParentEdges(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(1)
+	tree.Insert(7)
+
+	var buf bytes.Buffer
+	if err := tree.WriteDOT(&buf, DOTOptions{ParentEdges: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, edge := range []string{"n1 -> n0 [style=dashed", "n2 -> n0 [style=dashed"} {
+		if !strings.Contains(buf.String(), edge) {
+			t.Fatalf("Parent edge '%s' is missing: %s", edge, buf.String())
+		}
+	}
+
+	// a broken parent reference is visible
+	tree.node.left.p = &node[int, struct{}]{val: 42}
+	buf.Reset()
+	if err := tree.WriteDOT(&buf, DOTOptions{ParentEdges: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `[label="42", shape=doublecircle`) {
+		t.Fatalf("Dangling parent is missing: %s", buf.String())
+	}
+}
+
+func TestWriteDOTEscapesLabels(t *testing.T) {
+	tree := MakeTree[string]()
+	tree.Insert(`say "hi" \o/`)
+
+	var buf bytes.Buffer
+	if err := tree.WriteDOT(&buf, DOTOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `[label="say \"hi\" \\o/"`) {
+		t.Fatalf("Label is not escaped: %s", buf.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteDOTError(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(1)
+	if err := tree.WriteDOT(failingWriter{}, DOTOptions{}); err == nil {
+		t.Fatalf("Error of writer was not returned")
+	}
+}
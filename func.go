@@ -0,0 +1,148 @@
+package redblack
+
+import "iter"
+
+// A Red-Black Tree that is ordered by a custom comparison function
+//
+// This allows storing values that do not satisfy [Value], such
+// as structs or time.Time, or ordering values differently than
+// their natural order. Trees of [Value] types should use [Tree],
+// which does not need a comparison function.
+type TreeFunc[V any] struct {
+	rbtree[V, struct{}]
+	cmp func(a, b V) int
+}
+
+// MakeTreeFunc creates a new Red-Black Tree ordered by compare.
+//
+// The function must return a negative number if a < b, a positive
+// number if a > b and zero if both are equal, like [cmp.Compare].
+// Values that compare as equal are considered duplicates.
+func MakeTreeFunc[V any](compare func(a, b V) int) TreeFunc[V] {
+	return TreeFunc[V]{cmp: compare}
+}
+
+// Insert a value into a the tree.
+//
+// If the value already exists, nothing happens
+func (t *TreeFunc[V]) Insert(v V) {
+	t.insert(t.seek(v), v, struct{}{})
+}
+
+// Delete a value from the tree.
+//
+// Returns whether the value was in the tree
+func (t *TreeFunc[V]) Delete(v V) bool {
+	return t.delete(t.seek(v))
+}
+
+// Checks whether the specified value is in the tree
+func (t TreeFunc[V]) Contains(v V) bool {
+	return t.seek(v).match != nil
+}
+
+// Returns the height of the tree
+func (t TreeFunc[V]) Height() int {
+	return t.node.height(0)
+}
+
+// Returns the total number of nodes in the tree
+func (t TreeFunc[V]) Size() int {
+	return t.node.subtreeSize()
+}
+
+// Formats the string in a human readable format
+func (t TreeFunc[V]) String() string {
+	if t.node == nil {
+		return "EmptyTree"
+	} else {
+		return t.node.String()
+	}
+}
+
+// Returns the smallest value in the tree
+func (t TreeFunc[V]) Min() (V, bool) {
+	return t.node.min().value()
+}
+
+// Returns the biggest value in the tree
+func (t TreeFunc[V]) Max() (V, bool) {
+	return t.node.max().value()
+}
+
+// Removes and returns the smallest value in the tree
+func (t *TreeFunc[V]) PopMin() (V, bool) {
+	return t.pop(t.node.min())
+}
+
+// Removes and returns the biggest value in the tree
+func (t *TreeFunc[V]) PopMax() (V, bool) {
+	return t.pop(t.node.max())
+}
+
+// Returns an iterator over all values in ascending order.
+//
+// The tree is read when the loop starts, see [Tree.All].
+func (t *TreeFunc[V]) All() iter.Seq[V] {
+	return t.ascending()
+}
+
+// Returns an iterator over all values in descending order.
+//
+// The tree is read when the loop starts, see [Tree.All].
+func (t *TreeFunc[V]) Backward() iter.Seq[V] {
+	return t.descending()
+}
+
+// Returns an iterator over all values between lo and hi in ascending order.
+//
+// The bounds are looked up when the loop starts, see [Tree.Range].
+func (t *TreeFunc[V]) Range(lo, hi Bound[V]) iter.Seq[V] {
+	return t.between(lo, hi, t.seek)
+}
+
+// Returns the number of values between lo and hi
+func (t TreeFunc[V]) RangeCount(lo, hi Bound[V]) int {
+	return t.countBetween(t.lowerEnd(lo, t.seek), t.upperEnd(hi, t.seek))
+}
+
+// Returns the largest value that is smaller than or equal to v
+func (t TreeFunc[V]) Floor(v V) (V, bool) {
+	return t.seek(v).floor().value()
+}
+
+// Returns the smallest value that is bigger than or equal to v
+func (t TreeFunc[V]) Ceiling(v V) (V, bool) {
+	return t.seek(v).ceiling().value()
+}
+
+// Returns the largest value that is strictly smaller than v
+func (t TreeFunc[V]) Lower(v V) (V, bool) {
+	return t.seek(v).lower().value()
+}
+
+// Returns the smallest value that is strictly bigger than v
+func (t TreeFunc[V]) Higher(v V) (V, bool) {
+	return t.seek(v).higher().value()
+}
+
+// Returns the number of values in the tree that are smaller than v
+func (t TreeFunc[V]) Rank(v V) int {
+	return t.index(t.seek(v).ceiling())
+}
+
+// Returns the k-th smallest value in the tree (zero-based)
+func (t TreeFunc[V]) Select(k int) (V, bool) {
+	return t.node.nth(k).value()
+}
+
+// Checks that the tree is a valid Red-Black Tree according to the
+// comparison function. See [Tree.Validate].
+func (t TreeFunc[V]) Validate() error {
+	return t.validate(t.cmp)
+}
+
+// A pointer receiver, see [Tree.seek]
+func (t *TreeFunc[V]) seek(v V) position[V, struct{}] {
+	return seekFunc(t.node, v, t.cmp)
+}
@@ -0,0 +1,119 @@
+package redblack
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTreeFuncEmpty(t *testing.T) {
+	tree := MakeTreeFunc(strings.Compare)
+	if tree.Contains("a") {
+		t.Fatalf("Empty tree contains 'a'")
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Empty tree has not size 0")
+	}
+	if tree.String() != "EmptyTree" {
+		t.Fatalf("Empty tree is not 'EmptyTree' but '%s'", tree.String())
+	}
+}
+
+func TestTreeFuncTime(t *testing.T) {
+	tree := MakeTreeFunc(time.Time.Compare)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, h := range []int{5, 1, 3, 2, 4} {
+		tree.Insert(start.Add(time.Duration(h) * time.Hour))
+	}
+
+	if !tree.Contains(start.Add(3 * time.Hour)) {
+		t.Fatalf("Tree does not contain 03:00")
+	}
+	if v, ok := tree.Min(); !ok || !v.Equal(start.Add(time.Hour)) {
+		t.Fatalf("Expected 01:00 as minimum but got %v", v)
+	}
+	if v, ok := tree.Floor(start.Add(150 * time.Minute)); !ok || !v.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("Expected 02:00 as floor but got %v", v)
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestTreeFuncCaseInsensitive(t *testing.T) {
+	tree := MakeTreeFunc(func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	tree.Insert("Hello")
+	tree.Insert("hello")
+	tree.Insert("World")
+
+	if tree.Size() != 2 {
+		t.Fatalf("Expected 'Hello' and 'hello' to be duplicates")
+	}
+	if !tree.Contains("WORLD") {
+		t.Fatalf("Tree does not contain 'WORLD'")
+	}
+	if !tree.Delete("HELLO") {
+		t.Fatalf("Did not delete 'HELLO'")
+	}
+	if tree.Size() != 1 {
+		t.Fatalf("Tree has not size 1")
+	}
+}
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestTreeFuncStruct(t *testing.T) {
+	tree := MakeTreeFunc(func(a, b person) int {
+		return cmp.Or(cmp.Compare(a.age, b.age), strings.Compare(a.name, b.name))
+	})
+	people := []person{{"carol", 40}, {"alice", 30}, {"bob", 30}, {"dave", 20}}
+	for _, p := range people {
+		tree.Insert(p)
+	}
+
+	var names []string
+	for p := range tree.All() {
+		names = append(names, p.name)
+	}
+	if !slices.Equal(names, []string{"dave", "alice", "bob", "carol"}) {
+		t.Fatalf("Unexpected order %v", names)
+	}
+	if tree.Rank(person{"bob", 30}) != 2 {
+		t.Fatalf("Expected rank 2 for bob")
+	}
+	if p, ok := tree.Select(3); !ok || p.name != "carol" {
+		t.Fatalf("Expected carol at index 3 but got %v", p)
+	}
+	if count := tree.RangeCount(Inclusive(person{"", 30}), Exclusive(person{"", 40})); count != 2 {
+		t.Fatalf("Expected two people in their thirties but got %d", count)
+	}
+}
+
+func TestTreeFuncReverse(t *testing.T) {
+	tree := MakeTreeFunc(func(a, b int) int {
+		return cmp.Compare(b, a)
+	})
+	for i := range 100 {
+		tree.Insert(i)
+	}
+	for i := 0; i < 100; i += 2 {
+		tree.Delete(i)
+	}
+
+	values := slices.Collect(tree.All())
+	if len(values) != 50 || values[0] != 99 || values[49] != 1 {
+		t.Fatalf("Values are not in reverse order: %v", values)
+	}
+	if v, ok := tree.PopMin(); !ok || v != 99 {
+		t.Fatalf("Expected 99 as minimum but got %d", v)
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+}
@@ -0,0 +1,43 @@
+package redblack
+
+import "iter"
+
+// Returns an iterator over all values in ascending order.
+//
+// The tree is walked along the parent references, so neither
+// recursion nor an intermediate slice is needed. The tree is read
+// when the loop starts, so the iterator can be kept and reused
+// after later changes, but the tree must not be modified while
+// iterating.
+func (t *Tree[V]) All() iter.Seq[V] {
+	return t.ascending()
+}
+
+// Returns an iterator over all values in descending order.
+//
+// The tree is read when the loop starts, see [Tree.All].
+func (t *Tree[V]) Backward() iter.Seq[V] {
+	return t.descending()
+}
+
+// The root is looked up when the loop starts, since rotations may
+// have moved the node that was the root when the iterator was made
+func (t *rbtree[V, D]) ascending() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := t.node.min(); n != nil; n = n.next() {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+func (t *rbtree[V, D]) descending() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := t.node.max(); n != nil; n = n.prev() {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package redblack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAllEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	for v := range tree.All() {
+		t.Fatalf("Empty tree yielded %d", v)
+	}
+	for v := range tree.Backward() {
+		t.Fatalf("Empty tree yielded %d", v)
+	}
+}
+
+func TestAllSorted(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{10, 3, 15, 1, 2, 100, 4, 17, 16, 9, 75, 8, 11, 12, 33, 20, 5, 6, 7, 22, 13, 14, 88, 18, 19}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+
+	values := slices.Collect(tree.All())
+	expected := slices.Sorted(slices.Values(numbers))
+	if !slices.Equal(values, expected) {
+		t.Fatalf("Expected %v but got %v", expected, values)
+	}
+}
+
+func TestBackwardSorted(t *testing.T) {
+	tree := MakeTree[string]()
+	for _, w := range []string{"b", "d", "a", "c"} {
+		tree.Insert(w)
+	}
+
+	values := slices.Collect(tree.Backward())
+	expected := []string{"d", "c", "b", "a"}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("Expected %v but got %v", expected, values)
+	}
+}
+
+func TestAllBreak(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 100 {
+		tree.Insert(i)
+	}
+
+	var values []int
+	for v := range tree.All() {
+		if v == 3 {
+			break
+		}
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []int{0, 1, 2}) {
+		t.Fatalf("Expected [0 1 2] but got %v", values)
+	}
+}
+
+func TestBackwardBreak(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 100 {
+		tree.Insert(i)
+	}
+
+	var values []int
+	for v := range tree.Backward() {
+		if v == 96 {
+			break
+		}
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []int{99, 98, 97}) {
+		t.Fatalf("Expected [99 98 97] but got %v", values)
+	}
+}
+
+func TestAllAfterDelete(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 50 {
+		tree.Insert(i)
+	}
+	for i := 0; i < 50; i += 2 {
+		tree.Delete(i)
+	}
+
+	count := 0
+	for v := range tree.All() {
+		if v%2 == 0 {
+			t.Fatalf("Deleted value %d was yielded", v)
+		}
+		count++
+	}
+	if count != 25 {
+		t.Fatalf("Expected 25 values but got %d", count)
+	}
+}
+
+func TestAllReadsTreeWhenLoopStarts(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := 100; i > 90; i-- {
+		tree.Insert(i)
+	}
+	all, backward := tree.All(), tree.Backward()
+	// rotations move the old root down into a subtree
+	for i := range 90 {
+		tree.Insert(i)
+	}
+
+	values := slices.Collect(all)
+	if len(values) != 100 || !slices.IsSorted(values) {
+		t.Fatalf("Expected 100 sorted values but got %v", values)
+	}
+	values = slices.Collect(backward)
+	if len(values) != 100 || values[0] != 100 {
+		t.Fatalf("Expected 100 values from 100 down but got %v", values)
+	}
+}
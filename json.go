@@ -0,0 +1,189 @@
+package redblack
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Encodes the tree as a JSON array of the values in ascending order.
+//
+// This implements [json.Marshaler]. The structure of the tree is
+// not part of this format, see [Tree.Structural] for that.
+func (t Tree[V]) MarshalJSON() ([]byte, error) {
+	values := make([]V, 0, t.Size())
+	for v := range t.All() {
+		values = append(values, v)
+	}
+	return json.Marshal(values)
+}
+
+// Decodes a JSON array of values into the tree.
+//
+// This implements [json.Unmarshaler]. The values don't need to
+// be sorted and duplicates are ignored. Any values that were in
+// the tree before are removed.
+func (t *Tree[V]) UnmarshalJSON(data []byte) error {
+	var values []V
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	var tree Tree[V]
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	tree.observer = t.observer
+	*t = tree
+	return nil
+}
+
+// Encodes and decodes a tree with its structure.
+//
+// Every node is a JSON object with the value, the color, the value
+// of the parent and the two children, where a missing child is an
+// empty object. This is the format of [Tree.Json].
+type StructuralJSON[V Value] struct {
+	tree *Tree[V]
+}
+
+// Returns a wrapper to encode and decode the tree in the structural
+// JSON format, e.g. json.Marshal(tree.Structural())
+func (t *Tree[V]) Structural() *StructuralJSON[V] {
+	return &StructuralJSON[V]{tree: t}
+}
+
+// Implements [json.Marshaler]
+func (s *StructuralJSON[V]) MarshalJSON() ([]byte, error) {
+	acc, err := s.tree.node.json()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(acc), nil
+}
+
+// Implements [json.Unmarshaler]
+//
+// The decoded tree is validated and rejected if it is not a valid
+// Red-Black Tree or if the parents don't match.
+func (s *StructuralJSON[V]) UnmarshalJSON(data []byte) error {
+	var root jsonNode[V]
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+
+	n, err := root.decode(nil)
+	if err != nil {
+		return err
+	}
+
+	tree := Tree[V]{rbtree[V, struct{}]{node: n}}
+	if err := tree.validate(cmp.Compare[V]); err != nil {
+		return err
+	}
+	tree.observer = s.tree.observer
+	*s.tree = tree
+	return nil
+}
+
+type jsonNode[V Value] struct {
+	Value  *V              `json:"value"`
+	Color  string          `json:"color"`
+	Parent json.RawMessage `json:"parent"`
+	Left   *jsonNode[V]    `json:"left"`
+	Right  *jsonNode[V]    `json:"right"`
+}
+
+var nilParent = []byte(`"nil"`)
+
+// Creates the node with its children.
+//
+// An empty object is decoded as a missing node, which is nil
+func (j *jsonNode[V]) decode(parent *node[V, struct{}]) (*node[V, struct{}], error) {
+	if j == nil || (j.Value == nil && j.Color == "" && j.Parent == nil && j.Left == nil && j.Right == nil) {
+		return nil, nil
+	}
+	if j.Value == nil {
+		return nil, errors.New("node without value")
+	}
+
+	n := &node[V, struct{}]{val: *j.Value, p: parent}
+	switch j.Color {
+	case Black.String():
+		n.color = Black
+	case Red.String():
+		n.color = Red
+	default:
+		return nil, fmt.Errorf("node %s has invalid color '%s'", show(n.val), j.Color)
+	}
+
+	if parent == nil {
+		if !bytes.Equal(j.Parent, nilParent) {
+			return nil, fmt.Errorf("root %s has parent %s", show(n.val), j.Parent)
+		}
+	} else {
+		var p V
+		if err := json.Unmarshal(j.Parent, &p); err != nil || p != parent.val {
+			return nil, fmt.Errorf("node %s has parent %s, but is a child of %s", show(n.val), j.Parent, show(parent.val))
+		}
+	}
+
+	var err error
+	if n.left, err = j.Left.decode(n); err != nil {
+		return nil, err
+	}
+	if n.right, err = j.Right.decode(n); err != nil {
+		return nil, err
+	}
+	n.updateSize()
+	return n, nil
+}
+
+// Encodes the value with encoding/json
+func jsonLabel[V any](v V) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+func (n *node[V, D]) json() (string, error) {
+	d, err := makeDiagram(n, jsonLabel[V])
+	if err != nil {
+		return "", err
+	}
+	return d.json(d.root)
+}
+
+func (d *diagram[V, D]) json(n *diagramNode[V, D]) (string, error) {
+	if n == nil {
+		return "{}", nil
+	}
+
+	parent, ok, err := d.parentLabel(n)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		parent = string(nilParent)
+	}
+
+	left, err := d.json(n.left)
+	if err != nil {
+		return "", err
+	}
+	right, err := d.json(n.right)
+	if err != nil {
+		return "", err
+	}
+
+	acc := "{"
+	acc += "\"value\": " + n.label + ","
+	acc += "\"color\": \"" + n.node.color.String() + "\","
+	acc += "\"parent\": " + parent + ","
+	acc += "\"left\": " + left + ","
+	acc += "\"right\": " + right
+	acc += "}"
+
+	return acc, nil
+}
@@ -0,0 +1,155 @@
+package redblack
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSONEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[]" {
+		t.Fatalf("Expected '[]' but got '%s'", data)
+	}
+}
+
+func TestMarshalJSONSorted(t *testing.T) {
+	tree := MakeTree[string]()
+	for _, w := range []string{"b", `"quoted"`, "a"} {
+		tree.Insert(w)
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `["\"quoted\"","a","b"]`
+	if string(data) != expected {
+		t.Fatalf("Expected '%s' but got '%s'", expected, data)
+	}
+}
+
+func TestMarshalJSONNaN(t *testing.T) {
+	tree := MakeTree[float64]()
+	tree.Insert(math.NaN())
+	if _, err := json.Marshal(tree); err == nil {
+		t.Fatalf("NaN was encoded")
+	}
+	if tree.Json() != "" {
+		t.Fatalf("NaN was encoded in structure: '%s'", tree.Json())
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(100)
+	if err := json.Unmarshal([]byte("[5, 3, 9, 3, 1]"), &tree); err != nil {
+		t.Fatal(err)
+	}
+
+	values := slices.Collect(tree.All())
+	if !slices.Equal(values, []int{1, 3, 5, 9}) {
+		t.Fatalf("Expected [1 3 5 9] but got %v", values)
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(1)
+	if err := json.Unmarshal([]byte(`["a"]`), &tree); err == nil {
+		t.Fatalf("Decoded string into int tree")
+	}
+	if !tree.Contains(1) {
+		t.Fatalf("Failed decoding modified the tree")
+	}
+}
+
+func TestJsonString(t *testing.T) {
+	tree := MakeTree[string]()
+	tree.Insert("b")
+	tree.Insert("a")
+
+	expected := `{"value": "b","color": "black","parent": "nil","left": {"value": "a","color": "red","parent": "b","left": {},"right": {}},"right": {}}`
+	if tree.Json() != expected {
+		t.Fatalf("Expected '%s' but got '%s'", expected, tree.Json())
+	}
+	if !json.Valid([]byte(tree.Json())) {
+		t.Fatalf("Json is not valid")
+	}
+}
+
+func TestStructuralRoundTrip(t *testing.T) {
+	tree := MakeTree[string]()
+	for _, w := range strings.Fields(`the quick "brown" fox jumps over the lazy dog`) {
+		tree.Insert(w)
+	}
+
+	data, err := json.Marshal(tree.Structural())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(tree.Json())); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != compact.String() {
+		t.Fatalf("Structural format differs from Json: '%s'", data)
+	}
+
+	decoded := MakeTree[string]()
+	if err := json.Unmarshal(data, decoded.Structural()); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Json() != tree.Json() {
+		t.Fatalf("Expected '%s' but got '%s'", tree.Json(), decoded.Json())
+	}
+	validateTreeProperties(t, decoded.node)
+	validateParentRefs(t, decoded.node)
+	validateSizes(t, decoded.node)
+}
+
+func TestStructuralEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(1)
+	if err := json.Unmarshal([]byte("{}"), tree.Structural()); err != nil {
+		t.Fatal(err)
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Tree is not empty")
+	}
+}
+
+func TestStructuralRejectsCorruption(t *testing.T) {
+	inputs := map[string]string{
+		"red root":       `{"value": 5,"color": "red","parent": "nil","left": {},"right": {}}`,
+		"invalid color":  `{"value": 5,"color": "blue","parent": "nil","left": {},"right": {}}`,
+		"red red":        `{"value": 5,"color": "black","parent": "nil","left": {"value": 3,"color": "red","parent": 5,"left": {"value": 1,"color": "red","parent": 3,"left": {},"right": {}},"right": {}},"right": {"value": 7,"color": "black","parent": 5,"left": {},"right": {}}}`,
+		"black height":   `{"value": 5,"color": "black","parent": "nil","left": {"value": 1,"color": "black","parent": 5,"left": {},"right": {}},"right": {}}`,
+		"unordered":      `{"value": 5,"color": "black","parent": "nil","left": {"value": 7,"color": "red","parent": 5,"left": {},"right": {}},"right": {}}`,
+		"duplicate":      `{"value": 5,"color": "black","parent": "nil","left": {"value": 5,"color": "red","parent": 5,"left": {},"right": {}},"right": {}}`,
+		"wrong parent":   `{"value": 5,"color": "black","parent": "nil","left": {"value": 1,"color": "red","parent": 4,"left": {},"right": {}},"right": {}}`,
+		"root parent":    `{"value": 5,"color": "black","parent": 3,"left": {},"right": {}}`,
+		"missing value":  `{"color": "black","parent": "nil","left": {},"right": {}}`,
+		"invalid syntax": `{"value": 5,`,
+	}
+
+	for name, input := range inputs {
+		tree := MakeTree[int]()
+		tree.Insert(42)
+		if err := json.Unmarshal([]byte(input), tree.Structural()); err == nil {
+			t.Fatalf("Accepted corrupted input (%s)", name)
+		}
+		if !tree.Contains(42) || tree.Size() != 1 {
+			t.Fatalf("Rejected input (%s) modified the tree", name)
+		}
+	}
+}
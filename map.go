@@ -0,0 +1,103 @@
+package redblack
+
+import "iter"
+
+// An ordered map from keys to values
+//
+// The keys are kept in a Red-Black Tree, which means that
+// iterating over the map returns the entries sorted by key.
+type Map[K Value, V any] struct {
+	rbtree[K, V]
+}
+
+// MakeMap creates a new ordered map
+func MakeMap[K Value, V any]() Map[K, V] {
+	return Map[K, V]{}
+}
+
+// Associates the value with the key.
+//
+// If the key already exists, its value is replaced
+func (m *Map[K, V]) Put(k K, v V) {
+	if existing := m.insert(seek(m.node, k), k, v); existing != nil {
+		existing.data = v
+	}
+}
+
+// Returns the value associated with the key and whether the
+// key was found
+func (m Map[K, V]) Get(k K) (V, bool) {
+	n := seek(m.node, k).match
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.data, true
+}
+
+// Removes the key and its value from the map.
+//
+// Returns whether the key was in the map
+func (m *Map[K, V]) Delete(k K) bool {
+	return m.delete(seek(m.node, k))
+}
+
+// Returns the existing value for the key, if present.
+// Otherwise, the specified value is inserted and returned.
+//
+// The boolean is true if the value was already present
+func (m *Map[K, V]) GetOrInsert(k K, v V) (V, bool) {
+	if existing := m.insert(seek(m.node, k), k, v); existing != nil {
+		return existing.data, true
+	}
+	return v, false
+}
+
+// Replaces the value of the key with the result of f.
+//
+// The function receives the current value and whether the key
+// exists. If it does not exist, the key is inserted. The function
+// may modify the map, but if it deletes an existing key, the key
+// stays deleted.
+func (m *Map[K, V]) Update(k K, f func(old V, ok bool) V) {
+	if n := seek(m.node, k).match; n != nil {
+		n.data = f(n.data, true)
+		return
+	}
+
+	// the position is looked up after f, which may have changed the map
+	var zero V
+	m.Put(k, f(zero, false))
+}
+
+// Returns the number of entries in the map
+func (m Map[K, V]) Size() int {
+	return m.node.subtreeSize()
+}
+
+// Returns an iterator over all entries, ordered by key.
+//
+// The map is read when the loop starts, but must not be modified
+// while iterating.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := m.node.min(); n != nil; n = n.next() {
+			if !yield(n.val, n.data) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over all entries, in reverse order of the keys.
+//
+// The map is read when the loop starts, see [Map.All].
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := m.node.max(); n != nil; n = n.prev() {
+			if !yield(n.val, n.data) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package redblack
+
+import (
+	"testing"
+)
+
+func TestMapEmpty(t *testing.T) {
+	m := MakeMap[int, string]()
+	if _, ok := m.Get(5); ok {
+		t.Fatalf("Empty map contains 5")
+	}
+	if m.Size() != 0 {
+		t.Fatalf("Empty map has not size 0")
+	}
+	if m.Delete(5) {
+		t.Fatalf("Deleted 5 from empty map")
+	}
+}
+
+func TestMapPutGet(t *testing.T) {
+	m := MakeMap[int, string]()
+	m.Put(5, "five")
+	m.Put(3, "three")
+	m.Put(7, "seven")
+
+	if v, ok := m.Get(3); !ok || v != "three" {
+		t.Fatalf("Expected 'three' but got '%s'", v)
+	}
+	if m.Size() != 3 {
+		t.Fatalf("Map has not size 3")
+	}
+	validateTreeProperties(t, m.node)
+	validateParentRefs(t, m.node)
+}
+
+func TestMapPutReplaces(t *testing.T) {
+	m := MakeMap[string, int]()
+	m.Put("a", 1)
+	m.Put("a", 2)
+
+	if v, _ := m.Get("a"); v != 2 {
+		t.Fatalf("Expected 2 but got %d", v)
+	}
+	if m.Size() != 1 {
+		t.Fatalf("Map has not size 1")
+	}
+}
+
+func TestMapDelete(t *testing.T) {
+	m := MakeMap[int, int]()
+	for i := range 100 {
+		m.Put(i, i*i)
+	}
+	for i := 0; i < 100; i += 2 {
+		if !m.Delete(i) {
+			t.Fatalf("Did not delete %d", i)
+		}
+	}
+
+	for i := range 100 {
+		v, ok := m.Get(i)
+		if ok != (i%2 == 1) {
+			t.Fatalf("Expected Get(%d) to be found: %v", i, i%2 == 1)
+		}
+		if ok && v != i*i {
+			t.Fatalf("Expected %d but got %d", i*i, v)
+		}
+	}
+	validateTreeProperties(t, m.node)
+	validateParentRefs(t, m.node)
+}
+
+func TestMapGetOrInsert(t *testing.T) {
+	m := MakeMap[int, string]()
+	if v, ok := m.GetOrInsert(1, "one"); ok || v != "one" {
+		t.Fatalf("Expected 'one' to be inserted")
+	}
+	if v, ok := m.GetOrInsert(1, "uno"); !ok || v != "one" {
+		t.Fatalf("Expected existing 'one' but got '%s'", v)
+	}
+}
+
+func TestMapUpdate(t *testing.T) {
+	m := MakeMap[string, int]()
+	count := func(old int, ok bool) int {
+		return old + 1
+	}
+	for _, w := range []string{"a", "b", "a", "c", "a"} {
+		m.Update(w, count)
+	}
+
+	if v, _ := m.Get("a"); v != 3 {
+		t.Fatalf("Expected 3 but got %d", v)
+	}
+	if v, _ := m.Get("c"); v != 1 {
+		t.Fatalf("Expected 1 but got %d", v)
+	}
+}
+
+func TestMapUpdateModifiesMap(t *testing.T) {
+	m := MakeMap[int, int]()
+	m.Put(0, 0)
+	m.Put(2, 2)
+
+	// the rotations move the node where 1 was to be linked
+	m.Update(1, func(old int, ok bool) int {
+		for i := range 40 {
+			m.Put(1000+i, i)
+		}
+		return 7
+	})
+	m.Update(2, func(old int, ok bool) int {
+		m.Delete(0)
+		return old + 10
+	})
+
+	if v, ok := m.Get(1); !ok || v != 7 {
+		t.Fatalf("Expected 7 but got %d (%v)", v, ok)
+	}
+	if v, _ := m.Get(2); v != 12 {
+		t.Fatalf("Expected 12 but got %d", v)
+	}
+	count := 0
+	for range m.All() {
+		count++
+	}
+	if m.Size() != 42 || count != 42 {
+		t.Fatalf("Expected 42 entries but got size %d and %d entries", m.Size(), count)
+	}
+	validateTreeProperties(t, m.node)
+	validateParentRefs(t, m.node)
+	validateSizes(t, m.node)
+}
+
+func TestMapAll(t *testing.T) {
+	m := MakeMap[int, string]()
+	m.Put(2, "b")
+	m.Put(3, "c")
+	m.Put(1, "a")
+
+	var keys []int
+	var values string
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		values += v
+	}
+
+	if len(keys) != 3 || keys[0] != 1 || keys[1] != 2 || keys[2] != 3 {
+		t.Fatalf("Keys are not ordered: %v", keys)
+	}
+	if values != "abc" {
+		t.Fatalf("Expected 'abc' but got '%s'", values)
+	}
+}
+
+func TestMapAllBreak(t *testing.T) {
+	m := MakeMap[int, int]()
+	for i := range 10 {
+		m.Put(i, i)
+	}
+
+	count := 0
+	for k := range m.All() {
+		if k == 4 {
+			break
+		}
+		count++
+	}
+	if count != 4 {
+		t.Fatalf("Expected 4 iterations but got %d", count)
+	}
+}
+
+func TestMapBackward(t *testing.T) {
+	m := MakeMap[int, string]()
+	m.Put(2, "b")
+	m.Put(3, "c")
+	m.Put(1, "a")
+
+	var values string
+	for _, v := range m.Backward() {
+		values += v
+	}
+	if values != "cba" {
+		t.Fatalf("Expected 'cba' but got '%s'", values)
+	}
+}
+
+func TestMapAllReadsMapWhenLoopStarts(t *testing.T) {
+	m := MakeMap[int, int]()
+	for i := 99; i >= 90; i-- {
+		m.Put(i, i)
+	}
+	all := m.All()
+	for i := range 90 {
+		m.Put(i, i)
+	}
+
+	count := 0
+	for k, v := range all {
+		if k != count || v != count {
+			t.Fatalf("Expected %d but got %d=%d", count, k, v)
+		}
+		count++
+	}
+	if count != 100 {
+		t.Fatalf("Expected 100 entries but got %d", count)
+	}
+}
@@ -0,0 +1,144 @@
+package redblack
+
+import "iter"
+
+// A Red-Black Tree that keeps duplicates
+//
+// Instead of storing every copy of a value in its own node, each
+// node counts how many times its value was inserted.
+type MultiTree[V Value] struct {
+	rbtree[V, int]
+	total int
+}
+
+// MakeMultiTree creates a new Red-Black Tree that keeps duplicates
+func MakeMultiTree[V Value]() MultiTree[V] {
+	return MultiTree[V]{}
+}
+
+// Insert a copy of the value into the tree
+func (t *MultiTree[V]) Insert(v V) {
+	t.InsertN(v, 1)
+}
+
+// Insert n copies of the value into the tree.
+//
+// If n is not positive, nothing happens
+func (t *MultiTree[V]) InsertN(v V, n int) {
+	if n <= 0 {
+		return
+	}
+	if existing := t.insert(seek(t.node, v), v, n); existing != nil {
+		existing.data += n
+	}
+	t.total += n
+}
+
+// Returns how many copies of the value are in the tree
+func (t MultiTree[V]) Count(v V) int {
+	if n := seek(t.node, v).match; n != nil {
+		return n.data
+	}
+	return 0
+}
+
+// Checks whether at least one copy of the value is in the tree
+func (t MultiTree[V]) Contains(v V) bool {
+	return seek(t.node, v).match != nil
+}
+
+// Removes one copy of the value.
+//
+// Returns whether the value was in the tree
+func (t *MultiTree[V]) RemoveOne(v V) bool {
+	pos := seek(t.node, v)
+	if pos.match == nil {
+		return false
+	}
+	if pos.match.data > 1 {
+		pos.match.data--
+	} else {
+		t.remove(pos.match)
+	}
+	t.total--
+	return true
+}
+
+// Removes all copies of the value.
+//
+// Returns how many copies were removed
+func (t *MultiTree[V]) RemoveAll(v V) int {
+	pos := seek(t.node, v)
+	if pos.match == nil {
+		return 0
+	}
+	count := pos.match.data
+	t.remove(pos.match)
+	t.total -= count
+	return count
+}
+
+// Returns the total number of values in the tree, including duplicates
+func (t MultiTree[V]) Size() int {
+	return t.total
+}
+
+// Returns the number of distinct values in the tree
+func (t MultiTree[V]) Distinct() int {
+	return t.node.subtreeSize()
+}
+
+// Returns the smallest value in the tree
+func (t MultiTree[V]) Min() (V, bool) {
+	return t.node.min().value()
+}
+
+// Returns the biggest value in the tree
+func (t MultiTree[V]) Max() (V, bool) {
+	return t.node.max().value()
+}
+
+// Returns an iterator over all values in ascending order.
+//
+// Every value is yielded as many times as it was inserted.
+// The tree is read when the loop starts, but must not be modified
+// while iterating.
+func (t *MultiTree[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := t.node.min(); n != nil; n = n.next() {
+			for range n.data {
+				if !yield(n.val) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns an iterator over all values in descending order.
+//
+// Every value is yielded as many times as it was inserted.
+// The tree is read when the loop starts, see [MultiTree.All].
+func (t *MultiTree[V]) Backward() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := t.node.max(); n != nil; n = n.prev() {
+			for range n.data {
+				if !yield(n.val) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns an iterator over all distinct values and how many
+// times they are in the tree, in ascending order.
+func (t *MultiTree[V]) Counts() iter.Seq2[V, int] {
+	return func(yield func(V, int) bool) {
+		for n := t.node.min(); n != nil; n = n.next() {
+			if !yield(n.val, n.data) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package redblack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMultiTreeEmpty(t *testing.T) {
+	tree := MakeMultiTree[int]()
+	if tree.Count(5) != 0 || tree.Contains(5) {
+		t.Fatalf("Empty tree contains 5")
+	}
+	if tree.Size() != 0 || tree.Distinct() != 0 {
+		t.Fatalf("Empty tree has not size 0")
+	}
+	if tree.RemoveOne(5) || tree.RemoveAll(5) != 0 {
+		t.Fatalf("Removed 5 from empty tree")
+	}
+}
+
+func TestMultiTreeDuplicates(t *testing.T) {
+	tree := MakeMultiTree[string]()
+	for _, w := range []string{"b", "a", "b", "c", "b", "a"} {
+		tree.Insert(w)
+	}
+
+	if tree.Count("b") != 3 || tree.Count("a") != 2 || tree.Count("c") != 1 {
+		t.Fatalf("Unexpected counts %d, %d, %d", tree.Count("a"), tree.Count("b"), tree.Count("c"))
+	}
+	if tree.Size() != 6 {
+		t.Fatalf("Expected size 6 but got %d", tree.Size())
+	}
+	if tree.Distinct() != 3 {
+		t.Fatalf("Expected 3 distinct values but got %d", tree.Distinct())
+	}
+	if values := slices.Collect(tree.All()); !slices.Equal(values, []string{"a", "a", "b", "b", "b", "c"}) {
+		t.Fatalf("Unexpected values %v", values)
+	}
+	if values := slices.Collect(tree.Backward()); !slices.Equal(values, []string{"c", "b", "b", "b", "a", "a"}) {
+		t.Fatalf("Unexpected values %v", values)
+	}
+}
+
+func TestMultiTreeInsertN(t *testing.T) {
+	tree := MakeMultiTree[int]()
+	tree.InsertN(1, 3)
+	tree.InsertN(1, 2)
+	tree.InsertN(2, 0)
+	tree.InsertN(2, -1)
+
+	if tree.Count(1) != 5 {
+		t.Fatalf("Expected count 5 but got %d", tree.Count(1))
+	}
+	if tree.Contains(2) || tree.Size() != 5 {
+		t.Fatalf("Non-positive counts were inserted")
+	}
+}
+
+func TestMultiTreeRemoveOne(t *testing.T) {
+	tree := MakeMultiTree[int]()
+	tree.InsertN(1, 2)
+	tree.Insert(2)
+
+	if !tree.RemoveOne(1) || tree.Count(1) != 1 {
+		t.Fatalf("Did not remove exactly one copy")
+	}
+	if !tree.RemoveOne(1) || tree.Contains(1) {
+		t.Fatalf("Did not remove the last copy")
+	}
+	if tree.RemoveOne(1) {
+		t.Fatalf("Removed a value that is not in the tree")
+	}
+	if tree.Size() != 1 || tree.Distinct() != 1 {
+		t.Fatalf("Expected size 1 but got %d", tree.Size())
+	}
+}
+
+func TestMultiTreeRemoveAll(t *testing.T) {
+	tree := MakeMultiTree[int]()
+	for i := range 100 {
+		tree.InsertN(i, i%3+1)
+	}
+	for i := 0; i < 100; i += 2 {
+		if removed := tree.RemoveAll(i); removed != i%3+1 {
+			t.Fatalf("Expected to remove %d copies of %d but removed %d", i%3+1, i, removed)
+		}
+	}
+
+	total := 0
+	for v, count := range tree.Counts() {
+		if v%2 == 0 || count != v%3+1 {
+			t.Fatalf("Unexpected count %d for %d", count, v)
+		}
+		total += count
+	}
+	if tree.Size() != total {
+		t.Fatalf("Expected size %d but got %d", total, tree.Size())
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+}
+
+func TestMultiTreeAllReadsTreeWhenLoopStarts(t *testing.T) {
+	tree := MakeMultiTree[int]()
+	for i := 99; i >= 90; i-- {
+		tree.InsertN(i, 2)
+	}
+	all, counts := tree.All(), tree.Counts()
+	for i := range 90 {
+		tree.InsertN(i, 2)
+	}
+
+	if values := slices.Collect(all); len(values) != 200 || !slices.IsSorted(values) {
+		t.Fatalf("Expected 200 sorted values but got %v", values)
+	}
+	distinct := 0
+	for range counts {
+		distinct++
+	}
+	if distinct != 100 {
+		t.Fatalf("Expected 100 distinct values but got %d", distinct)
+	}
+}
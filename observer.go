@@ -0,0 +1,97 @@
+package redblack
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Gets notified about the changes in a tree, see [Tree.SetObserver]
+//
+// The methods are called synchronously while the tree is modified,
+// which means they must not access the tree themselves.
+type Observer[V any] interface {
+	// A new value has been linked into the tree as a red leaf,
+	// before the properties are restored
+	OnInsert(v V)
+	// A value is about to be removed from the tree
+	OnDelete(v V)
+	// The node with the pivot moves down in the given direction
+	OnRotate(dir Direction, pivot V)
+	// The node with the value changed its color while the properties
+	// are restored. Swapping a deleted node with its successor keeps
+	// the colors with the positions and is not reported.
+	OnRecolor(v V, from, to Color)
+}
+
+// Registers an observer that is notified about inserts, deletes
+// and the rebalancing work they cause. Passing nil removes it.
+//
+// Without an observer, the only cost is a nil check. Clones and
+// the results of set operations don't inherit the observer.
+func (t *Tree[V]) SetObserver(o Observer[V]) {
+	t.observer = o
+}
+
+// An [Observer] that writes a log record for every notification
+type SlogObserver[V any] struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+// MakeSlogObserver creates an observer that logs with the logger
+// at the level
+func MakeSlogObserver[V any](logger *slog.Logger, level slog.Level) SlogObserver[V] {
+	return SlogObserver[V]{logger: logger, level: level}
+}
+
+func (o SlogObserver[V]) OnInsert(v V) {
+	o.logger.LogAttrs(context.Background(), o.level, "insert", slog.Any("value", v))
+}
+
+func (o SlogObserver[V]) OnDelete(v V) {
+	o.logger.LogAttrs(context.Background(), o.level, "delete", slog.Any("value", v))
+}
+
+func (o SlogObserver[V]) OnRotate(dir Direction, pivot V) {
+	o.logger.LogAttrs(context.Background(), o.level, "rotate", slog.String("direction", dir.String()), slog.Any("pivot", pivot))
+}
+
+func (o SlogObserver[V]) OnRecolor(v V, from, to Color) {
+	o.logger.LogAttrs(context.Background(), o.level, "recolor", slog.Any("value", v), slog.String("from", from.String()), slog.String("to", to.String()))
+}
+
+// An [Observer] that counts the notifications.
+//
+// Register a pointer to it, so the counters are updated.
+type CountingObserver[V any] struct {
+	Inserts        int
+	Deletes        int
+	LeftRotations  int
+	RightRotations int
+	Recolors       int
+}
+
+func (o *CountingObserver[V]) OnInsert(v V) {
+	o.Inserts++
+}
+
+func (o *CountingObserver[V]) OnDelete(v V) {
+	o.Deletes++
+}
+
+func (o *CountingObserver[V]) OnRotate(dir Direction, pivot V) {
+	if dir == RotateLeft {
+		o.LeftRotations++
+	} else {
+		o.RightRotations++
+	}
+}
+
+func (o *CountingObserver[V]) OnRecolor(v V, from, to Color) {
+	o.Recolors++
+}
+
+// Returns the number of rotations in both directions
+func (o *CountingObserver[V]) Rotations() int {
+	return o.LeftRotations + o.RightRotations
+}
@@ -0,0 +1,140 @@
+package redblack
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) OnInsert(v int) {
+	o.events = append(o.events, "insert "+show(v))
+}
+
+func (o *recordingObserver) OnDelete(v int) {
+	o.events = append(o.events, "delete "+show(v))
+}
+
+func (o *recordingObserver) OnRotate(dir Direction, pivot int) {
+	o.events = append(o.events, "rotate "+dir.String()+" "+show(pivot))
+}
+
+func (o *recordingObserver) OnRecolor(v int, from, to Color) {
+	o.events = append(o.events, "recolor "+show(v)+" "+from.String()+" "+to.String())
+}
+
+func TestObserverInsert(t *testing.T) {
+	tree := MakeTree[int]()
+	var o recordingObserver
+	tree.SetObserver(&o)
+
+	tree.Insert(5)
+	tree.Insert(3)
+	tree.Insert(4)
+	tree.Insert(4)
+
+	expected := []string{
+		"insert 5",
+		"recolor 5 red black",
+		"insert 3",
+		"insert 4",
+		"rotate left 3",
+		"recolor 4 red black",
+		"recolor 5 black red",
+		"rotate right 5",
+	}
+	if strings.Join(o.events, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Expected %v but got %v", expected, o.events)
+	}
+}
+
+func TestObserverDelete(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 8, 1} {
+		tree.Insert(v)
+	}
+	var o recordingObserver
+	tree.SetObserver(&o)
+
+	tree.Delete(8)
+	tree.Delete(8)
+
+	expected := []string{
+		"delete 8",
+		"recolor 1 red black",
+		"rotate right 5",
+	}
+	if strings.Join(o.events, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Expected %v but got %v", expected, o.events)
+	}
+	validateTreeProperties(t, tree.node)
+}
+
+func TestCountingObserver(t *testing.T) {
+	tree := MakeTree[int]()
+	var counter CountingObserver[int]
+	tree.SetObserver(&counter)
+
+	for i := range 100 {
+		tree.Insert(i)
+	}
+	for i := range 50 {
+		tree.Delete(i)
+	}
+
+	if counter.Inserts != 100 || counter.Deletes != 50 {
+		t.Fatalf("Expected 100 inserts and 50 deletes but got %d and %d", counter.Inserts, counter.Deletes)
+	}
+	if counter.Rotations() == 0 || counter.Recolors == 0 {
+		t.Fatalf("No rebalancing was counted: %+v", counter)
+	}
+
+	tree.SetObserver(nil)
+	tree.Insert(1000)
+	if counter.Inserts != 100 {
+		t.Fatalf("Removed observer was notified")
+	}
+}
+
+func TestSlogObserver(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	tree := MakeTree[int]()
+	tree.SetObserver(MakeSlogObserver[int](logger, slog.LevelDebug))
+	tree.Insert(1)
+	tree.Insert(2)
+	tree.Insert(3)
+
+	out := buf.String()
+	for _, expected := range []string{
+		"level=DEBUG msg=insert value=3",
+		"msg=recolor value=1 from=red to=black",
+		"msg=rotate direction=left pivot=1",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("Log does not contain '%s':\n%s", expected, out)
+		}
+	}
+}
+
+func TestObserverIgnoresSwapWithSuccessor(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 3 {
+		tree.Insert(i)
+	}
+	var counter CountingObserver[int]
+	tree.SetObserver(&counter)
+
+	// the root has two children and is swapped with its
+	// successor, which is a red leaf and needs no fix-up
+	tree.Delete(1)
+	if counter.Deletes != 1 || counter.Recolors != 0 || counter.Rotations() != 0 {
+		t.Fatalf("Expected a single delete but got %+v", counter)
+	}
+	validateTreeProperties(t, tree.node)
+}
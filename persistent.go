@@ -0,0 +1,474 @@
+package redblack
+
+import (
+	"iter"
+	"slices"
+)
+
+// An immutable Red-Black Tree
+//
+// Insert and Delete return a new tree and leave the original one
+// untouched. Only the nodes on the path from the root to the
+// modified node are copied, all other subtrees are shared between
+// the versions. Copying a PersistentTree is therefore a cheap
+// snapshot, which is never affected by later modifications.
+//
+// The nodes don't have parent references, because a shared
+// subtree can have many parents.
+type PersistentTree[V Value] struct {
+	root *pnode[V]
+}
+
+// MakePersistentTree creates a new, empty immutable Red-Black Tree
+func MakePersistentTree[V Value]() PersistentTree[V] {
+	return PersistentTree[V]{}
+}
+
+type pnode[V Value] struct {
+	val   V
+	color Color
+	size  int // number of nodes in this subtree
+	left  *pnode[V]
+	right *pnode[V]
+}
+
+// The copied path from the root to a node.
+//
+// All nodes in the path are copies, which means they can be
+// modified without affecting other versions of the tree. Any
+// other node must be copied before it is modified.
+type ppath[V Value] struct {
+	root  *pnode[V]
+	nodes []*pnode[V]
+}
+
+// Returns a tree that additionally contains the value.
+//
+// If the value already exists, the same tree is returned
+func (t PersistentTree[V]) Insert(v V) PersistentTree[V] {
+	var nodes []*pnode[V]
+	for n := t.root; n != nil; {
+		if v == n.val {
+			return t
+		}
+		nodes = append(nodes, n)
+		if v < n.val {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	path := copyPath(nodes)
+	for _, n := range path.nodes {
+		n.size++
+	}
+
+	n := &pnode[V]{val: v, color: Red, size: 1}
+	if len(path.nodes) == 0 {
+		path.root = n
+	} else if parent := path.nodes[len(path.nodes)-1]; v < parent.val {
+		parent.left = n
+	} else {
+		parent.right = n
+	}
+	path.nodes = append(path.nodes, n)
+
+	path.fixInsert()
+	path.root.color = Black
+	return PersistentTree[V]{path.root}
+}
+
+// Returns a tree without the value.
+//
+// If the value does not exist, the same tree is returned
+func (t PersistentTree[V]) Delete(v V) PersistentTree[V] {
+	var nodes []*pnode[V]
+	n := t.root
+	for n != nil && n.val != v {
+		nodes = append(nodes, n)
+		if v < n.val {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	if n == nil {
+		return t
+	}
+
+	// a node with two children takes the value of its
+	// successor, which is removed instead
+	found := len(nodes)
+	nodes = append(nodes, n)
+	if n.left != nil && n.right != nil {
+		for n = n.right; n != nil; n = n.left {
+			nodes = append(nodes, n)
+		}
+	}
+
+	path := copyPath(nodes)
+	removed := path.nodes[len(path.nodes)-1]
+	path.nodes = path.nodes[:len(path.nodes)-1]
+	if found < len(path.nodes) {
+		path.nodes[found].val = removed.val
+	}
+	for _, n := range path.nodes {
+		n.size--
+	}
+
+	child := removed.left
+	if child == nil {
+		child = removed.right
+	}
+
+	parent := len(path.nodes) - 1
+	if child != nil {
+		// a single child is always red
+		child = child.clone()
+		child.color = Black
+		path.replace(parent, removed, child)
+	} else if parent < 0 {
+		path.root = nil
+	} else {
+		left := path.nodes[parent].left == removed
+		path.replace(parent, removed, nil)
+		if removed.color == Black {
+			path.fixDoubleBlack(parent, left)
+		}
+	}
+
+	if path.root != nil {
+		path.root.color = Black
+	}
+	return PersistentTree[V]{path.root}
+}
+
+// Checks whether the specified value is in the tree
+func (t PersistentTree[V]) Contains(v V) bool {
+	n := t.root
+	for n != nil && n.val != v {
+		if v < n.val {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return n != nil
+}
+
+// Returns the total number of nodes in the tree
+func (t PersistentTree[V]) Size() int {
+	return t.root.subtreeSize()
+}
+
+// Returns the height of the tree
+func (t PersistentTree[V]) Height() int {
+	return t.root.height()
+}
+
+// Returns the smallest value in the tree
+func (t PersistentTree[V]) Min() (V, bool) {
+	n := t.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n.value()
+}
+
+// Returns the biggest value in the tree
+func (t PersistentTree[V]) Max() (V, bool) {
+	n := t.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.value()
+}
+
+// Returns the largest value that is smaller than or equal to v
+func (t PersistentTree[V]) Floor(v V) (V, bool) {
+	var candidate *pnode[V]
+	for n := t.root; n != nil; {
+		if n.val <= v {
+			candidate = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return candidate.value()
+}
+
+// Returns the smallest value that is bigger than or equal to v
+func (t PersistentTree[V]) Ceiling(v V) (V, bool) {
+	var candidate *pnode[V]
+	for n := t.root; n != nil; {
+		if n.val >= v {
+			candidate = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return candidate.value()
+}
+
+// Returns an iterator over all values in ascending order.
+//
+// Since the tree is immutable, it is safe to create new
+// versions of the tree while iterating.
+func (t PersistentTree[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var stack []*pnode[V]
+		for n := t.root; n != nil || len(stack) > 0; n = n.right {
+			for ; n != nil; n = n.left {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over all values in descending order.
+func (t PersistentTree[V]) Backward() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var stack []*pnode[V]
+		for n := t.root; n != nil || len(stack) > 0; n = n.left {
+			for ; n != nil; n = n.right {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Copies all nodes and links the copies to each other, where
+// every node must be a child of the previous one.
+func copyPath[V Value](nodes []*pnode[V]) ppath[V] {
+	path := ppath[V]{nodes: make([]*pnode[V], len(nodes))}
+	for i, n := range nodes {
+		c := n.clone()
+		if i == 0 {
+			path.root = c
+		} else {
+			path.nodes[i-1].relink(n, c)
+		}
+		path.nodes[i] = c
+	}
+	return path
+}
+
+// Replaces the child old of the i-th node with new. If i is
+// negative, old is the root.
+func (path *ppath[V]) replace(i int, old, new *pnode[V]) {
+	if i < 0 {
+		path.root = new
+	} else {
+		path.nodes[i].relink(old, new)
+	}
+}
+
+// Restores the properties after the last node of the path was
+// inserted. This is the same as [rbtree.fixViolations], except that
+// the parents are taken from the path.
+func (path *ppath[V]) fixInsert() {
+	for i := len(path.nodes) - 1; i >= 2; i -= 2 {
+		n, p, g := path.nodes[i], path.nodes[i-1], path.nodes[i-2]
+		if p.color != Red {
+			return
+		}
+
+		uncle := g.left
+		if uncle == p {
+			uncle = g.right
+		}
+
+		if !uncle.isBlack() {
+			// scenario 2
+			c := uncle.clone()
+			g.relink(uncle, c)
+			c.color = Black
+			p.color = Black
+			g.color = Red
+			continue
+		}
+
+		if g.left == p {
+			if p.right == n {
+				// scenario 3
+				g.left = p.rotateLeft()
+				p = n
+			}
+			// scenario 4
+			path.replace(i-3, g, g.rotateRight())
+		} else {
+			if p.left == n {
+				// scenario 3
+				g.right = p.rotateRight()
+				p = n
+			}
+			// scenario 4
+			path.replace(i-3, g, g.rotateLeft())
+		}
+		p.color = Black
+		g.color = Red
+		return
+	}
+}
+
+// Fixes the missing black node on the left or right side of the
+// i-th node of the path. This follows the cases of
+// [rbtree.fixDoubleBlack].
+func (path *ppath[V]) fixDoubleBlack(i int, left bool) {
+	for i >= 0 {
+		p := path.nodes[i]
+
+		var sibling *pnode[V]
+		if left {
+			sibling = p.right.clone()
+			p.right = sibling
+		} else {
+			sibling = p.left.clone()
+			p.left = sibling
+		}
+
+		if sibling.color == Red {
+			// case 1
+			sibling.color = Black
+			p.color = Red
+			if left {
+				path.replace(i-1, p, p.rotateLeft())
+			} else {
+				path.replace(i-1, p, p.rotateRight())
+			}
+			// the sibling is now between p and its parent
+			path.nodes = slices.Insert(path.nodes, i, sibling)
+			i++
+			continue
+		}
+
+		if sibling.left.isBlack() && sibling.right.isBlack() {
+			// case 2
+			sibling.color = Red
+			if p.color == Red {
+				p.color = Black
+				return
+			}
+			if i > 0 {
+				left = path.nodes[i-1].left == p
+			}
+			i--
+			continue
+		}
+
+		if left {
+			if sibling.right.isBlack() {
+				// case 3
+				sibling.left = sibling.left.clone()
+				sibling.left.color = Black
+				sibling.color = Red
+				sibling = sibling.rotateRight()
+				p.right = sibling
+			}
+			// case 4
+			sibling.right = sibling.right.clone()
+			sibling.right.color = Black
+			sibling.color = p.color
+			p.color = Black
+			path.replace(i-1, p, p.rotateLeft())
+		} else {
+			if sibling.left.isBlack() {
+				// case 3
+				sibling.right = sibling.right.clone()
+				sibling.right.color = Black
+				sibling.color = Red
+				sibling = sibling.rotateLeft()
+				p.left = sibling
+			}
+			// case 4
+			sibling.left = sibling.left.clone()
+			sibling.left.color = Black
+			sibling.color = p.color
+			p.color = Black
+			path.replace(i-1, p, p.rotateRight())
+		}
+		return
+	}
+}
+
+func (n *pnode[V]) clone() *pnode[V] {
+	c := *n
+	return &c
+}
+
+// Replaces the child old with new
+func (n *pnode[V]) relink(old, new *pnode[V]) {
+	if n.left == old {
+		n.left = new
+	} else {
+		n.right = new
+	}
+}
+
+// Rotates n to the left and returns the new root of the subtree.
+//
+// Both n and its right child must be copies.
+func (n *pnode[V]) rotateLeft() *pnode[V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.updateSize()
+	r.updateSize()
+	return r
+}
+
+// Rotates n to the right and returns the new root of the subtree.
+//
+// Both n and its left child must be copies.
+func (n *pnode[V]) rotateRight() *pnode[V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.updateSize()
+	l.updateSize()
+	return l
+}
+
+// leaves are black, so nil is black
+func (n *pnode[V]) isBlack() bool {
+	return n == nil || n.color == Black
+}
+
+func (n *pnode[V]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *pnode[V]) updateSize() {
+	n.size = n.left.subtreeSize() + n.right.subtreeSize() + 1
+}
+
+func (n *pnode[V]) height() int {
+	if n == nil {
+		return 0
+	}
+	return max(n.left.height(), n.right.height()) + 1
+}
+
+func (n *pnode[V]) value() (V, bool) {
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.val, true
+}
@@ -0,0 +1,222 @@
+package redblack
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestPersistentEmpty(t *testing.T) {
+	tree := MakePersistentTree[int]()
+	if tree.Contains(5) {
+		t.Fatalf("Empty tree contains 5")
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Empty tree has not size 0")
+	}
+	if _, ok := tree.Min(); ok {
+		t.Fatalf("Empty tree has a minimum")
+	}
+	if tree.Delete(5).Size() != 0 {
+		t.Fatalf("Deleting from empty tree added values")
+	}
+}
+
+func TestPersistentInsertKeepsOriginal(t *testing.T) {
+	v1 := MakePersistentTree[int]().Insert(5).Insert(3)
+	v2 := v1.Insert(7)
+
+	if v1.Contains(7) {
+		t.Fatalf("Original tree contains 7")
+	}
+	if !v2.Contains(7) || !v2.Contains(5) || !v2.Contains(3) {
+		t.Fatalf("New tree does not contain all values")
+	}
+	if v1.Size() != 2 || v2.Size() != 3 {
+		t.Fatalf("Expected sizes 2 and 3 but got %d and %d", v1.Size(), v2.Size())
+	}
+}
+
+func TestPersistentInsertDuplicate(t *testing.T) {
+	v1 := MakePersistentTree[int]().Insert(5)
+	v2 := v1.Insert(5)
+	if v1.root != v2.root {
+		t.Fatalf("Inserting an existing value copied the tree")
+	}
+}
+
+func TestPersistentSharesSubtrees(t *testing.T) {
+	tree := MakePersistentTree[int]()
+	for i := range 100 {
+		tree = tree.Insert(i)
+	}
+	next := tree.Insert(1000)
+
+	if tree.root.left != next.root.left {
+		t.Fatalf("Left subtree was copied when inserting on the right")
+	}
+}
+
+func TestPersistentInsertMany(t *testing.T) {
+	tree := MakePersistentTree[int]()
+	var versions []PersistentTree[int]
+	numbers := []int{10, 3, 15, 1, 2, 100, 4, 17, 16, 9, 75, 8, 11, 12, 33, 20, 5, 6, 7, 22, 13, 14, 88, 18, 19}
+	for _, v := range numbers {
+		tree = tree.Insert(v)
+		versions = append(versions, tree)
+		validatePersistentTree(t, tree)
+	}
+
+	for i, version := range versions {
+		if version.Size() != i+1 {
+			t.Fatalf("Version %d has size %d", i, version.Size())
+		}
+		for j, v := range numbers {
+			if version.Contains(v) != (j <= i) {
+				t.Fatalf("Version %d contains %d: %v", i, v, j > i)
+			}
+		}
+	}
+}
+
+func TestPersistentDelete(t *testing.T) {
+	tree := MakePersistentTree[int]()
+	for i := range 1000 {
+		tree = tree.Insert(i)
+	}
+	original := tree
+
+	for i := 0; i < 1000; i += 3 {
+		tree = tree.Delete(i)
+		validatePersistentTree(t, tree)
+	}
+	for i := 999; i >= 0; i -= 7 {
+		tree = tree.Delete(i)
+		validatePersistentTree(t, tree)
+	}
+
+	for i := range 1000 {
+		expected := i%3 != 0 && (999-i)%7 != 0
+		if tree.Contains(i) != expected {
+			t.Fatalf("Expected Contains(%d) to be %v", i, expected)
+		}
+		if !original.Contains(i) {
+			t.Fatalf("Original tree lost %d", i)
+		}
+	}
+	validatePersistentTree(t, original)
+}
+
+func TestPersistentDeleteAll(t *testing.T) {
+	tree := MakePersistentTree[int]()
+	numbers := []int{10, 3, 15, 1, 2, 100, 4, 17, 16, 9, 75, 8, 11, 12, 33, 20, 5, 6, 7, 22, 13, 14, 88, 18, 19}
+	for _, v := range numbers {
+		tree = tree.Insert(v)
+	}
+	for i, v := range numbers {
+		next := tree.Delete(v)
+		if next.Contains(v) || !tree.Contains(v) {
+			t.Fatalf("Delete of %d is not isolated", v)
+		}
+		tree = next
+		if tree.Size() != len(numbers)-i-1 {
+			t.Fatalf("Tree does not have size %d", len(numbers)-i-1)
+		}
+		validatePersistentTree(t, tree)
+	}
+}
+
+func TestPersistentDeleteMissing(t *testing.T) {
+	tree := MakePersistentTree[int]().Insert(1).Insert(2)
+	if tree.Delete(3).root != tree.root {
+		t.Fatalf("Deleting a missing value copied the tree")
+	}
+}
+
+func TestPersistentIterators(t *testing.T) {
+	tree := MakePersistentTree[string]()
+	for _, w := range []string{"b", "d", "a", "c"} {
+		tree = tree.Insert(w)
+	}
+
+	if values := slices.Collect(tree.All()); !slices.Equal(values, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("Unexpected values %v", values)
+	}
+	if values := slices.Collect(tree.Backward()); !slices.Equal(values, []string{"d", "c", "b", "a"}) {
+		t.Fatalf("Unexpected values %v", values)
+	}
+
+	for v := range tree.All() {
+		tree = tree.Delete(v)
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Deleting while iterating did not remove all values")
+	}
+}
+
+func TestPersistentNeighbours(t *testing.T) {
+	tree := MakePersistentTree[int]()
+	for i := range 10 {
+		tree = tree.Insert(i * 10)
+	}
+
+	if v, ok := tree.Floor(25); !ok || v != 20 {
+		t.Fatalf("Expected floor 20 but got %d", v)
+	}
+	if v, ok := tree.Ceiling(25); !ok || v != 30 {
+		t.Fatalf("Expected ceiling 30 but got %d", v)
+	}
+	if _, ok := tree.Ceiling(95); ok {
+		t.Fatalf("Expected no ceiling for 95")
+	}
+	if v, ok := tree.Max(); !ok || v != 90 {
+		t.Fatalf("Expected maximum 90 but got %d", v)
+	}
+}
+
+func validatePersistentTree[V Value](t *testing.T, tree PersistentTree[V]) {
+	if tree.root == nil {
+		return
+	}
+	if tree.root.color != Black {
+		t.Fatalf("Root is not black")
+	}
+	if _, err := validatePersistentNode(tree.root); err != nil {
+		t.Fatal(err)
+	}
+
+	values := slices.Collect(tree.All())
+	if !slices.IsSorted(values) || len(slices.Compact(values)) != tree.Size() {
+		t.Fatalf("Values are not sorted or size is wrong: %v", values)
+	}
+}
+
+func validatePersistentNode[V Value](n *pnode[V]) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+
+	if n.color == Red && (!n.left.isBlack() || !n.right.isBlack()) {
+		return 0, fmt.Errorf("Red node %s has red child", show(n.val))
+	}
+	if expected := n.left.subtreeSize() + n.right.subtreeSize() + 1; n.size != expected {
+		return 0, fmt.Errorf("%s has size %d, but subtree has %d nodes", show(n.val), n.size, expected)
+	}
+
+	left, err := validatePersistentNode(n.left)
+	if err != nil {
+		return 0, err
+	}
+	right, err := validatePersistentNode(n.right)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("Left child of %s has %d black nodes, but right child has %d", show(n.val), left, right)
+	}
+
+	if n.color == Black {
+		left++
+	}
+	return left, nil
+}
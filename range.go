@@ -0,0 +1,126 @@
+package redblack
+
+import "iter"
+
+type boundKind int
+
+const (
+	unbounded boundKind = 0
+	inclusive boundKind = 1
+	exclusive boundKind = 2
+)
+
+// One end of a range of values.
+//
+// The zero value is unbounded.
+type Bound[V any] struct {
+	val  V
+	kind boundKind
+}
+
+// Inclusive creates a bound that includes the value itself
+func Inclusive[V any](v V) Bound[V] {
+	return Bound[V]{val: v, kind: inclusive}
+}
+
+// Exclusive creates a bound that excludes the value itself
+func Exclusive[V any](v V) Bound[V] {
+	return Bound[V]{val: v, kind: exclusive}
+}
+
+// Unbounded creates a bound that does not restrict the range
+func Unbounded[V any]() Bound[V] {
+	return Bound[V]{}
+}
+
+// Returns an iterator over all values between lo and hi in ascending order.
+//
+// The first value is found in O(log n), from where the
+// successors are walked up to the upper bound. The bounds are
+// looked up when the loop starts, so the iterator can be kept and
+// reused after later changes, but the tree must not be modified
+// while iterating.
+func (t *Tree[V]) Range(lo, hi Bound[V]) iter.Seq[V] {
+	return t.between(lo, hi, t.seek)
+}
+
+// Returns the number of values between lo and hi
+//
+// The sizes of the subtrees are used, so this is O(log n)
+// regardless of how many values are in the range.
+func (t Tree[V]) RangeCount(lo, hi Bound[V]) int {
+	return t.countBetween(t.lowerEnd(lo, t.seek), t.upperEnd(hi, t.seek))
+}
+
+// Returns the largest value that is smaller than or equal to v
+func (t Tree[V]) Floor(v V) (V, bool) {
+	return t.seek(v).floor().value()
+}
+
+// Returns the smallest value that is bigger than or equal to v
+func (t Tree[V]) Ceiling(v V) (V, bool) {
+	return t.seek(v).ceiling().value()
+}
+
+// Returns the largest value that is strictly smaller than v
+func (t Tree[V]) Lower(v V) (V, bool) {
+	return t.seek(v).lower().value()
+}
+
+// Returns the smallest value that is strictly bigger than v
+func (t Tree[V]) Higher(v V) (V, bool) {
+	return t.seek(v).higher().value()
+}
+
+// Returns the first node that is admitted by the lower bound
+func (t rbtree[V, D]) lowerEnd(lo Bound[V], seek func(V) position[V, D]) *node[V, D] {
+	switch lo.kind {
+	case inclusive:
+		return seek(lo.val).ceiling()
+	case exclusive:
+		return seek(lo.val).higher()
+	default:
+		return t.node.min()
+	}
+}
+
+// Returns the first node that is not admitted by the upper bound
+func (t rbtree[V, D]) upperEnd(hi Bound[V], seek func(V) position[V, D]) *node[V, D] {
+	switch hi.kind {
+	case inclusive:
+		return seek(hi.val).higher()
+	case exclusive:
+		return seek(hi.val).ceiling()
+	default:
+		return nil
+	}
+}
+
+// Returns an iterator over the nodes admitted by lo and hi. They are
+// looked up when the loop starts, so seek must read the current root.
+func (t *rbtree[V, D]) between(lo, hi Bound[V], seek func(V) position[V, D]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		start := t.lowerEnd(lo, seek)
+		count := t.countBetween(start, t.upperEnd(hi, seek))
+		for n := start; count > 0; n = n.next() {
+			if !yield(n.val) {
+				return
+			}
+			count--
+		}
+	}
+}
+
+// Returns the number of nodes from start up to (excluding) end
+func (t rbtree[V, D]) countBetween(start, end *node[V, D]) int {
+	return max(0, t.index(end)-t.index(start))
+}
+
+// Returns the value of the node and whether there is a node at all
+func (n *node[V, D]) value() (V, bool) {
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.val, true
+}
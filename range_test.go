@@ -0,0 +1,192 @@
+package redblack
+
+import (
+	"slices"
+	"testing"
+)
+
+func makeRangeTree() Tree[int] {
+	tree := MakeTree[int]()
+	for i := range 20 {
+		tree.Insert(i * 2)
+	}
+	return tree
+}
+
+func TestRangeEmptyTree(t *testing.T) {
+	tree := MakeTree[int]()
+	for v := range tree.Range(Unbounded[int](), Unbounded[int]()) {
+		t.Fatalf("Empty tree yielded %d", v)
+	}
+	if tree.RangeCount(Unbounded[int](), Unbounded[int]()) != 0 {
+		t.Fatalf("Empty tree has range count")
+	}
+}
+
+func TestRangeUnbounded(t *testing.T) {
+	tree := makeRangeTree()
+	values := slices.Collect(tree.Range(Unbounded[int](), Unbounded[int]()))
+	if !slices.Equal(values, slices.Collect(tree.All())) {
+		t.Fatalf("Unbounded range is not the whole tree: %v", values)
+	}
+}
+
+func TestRangeZeroBoundIsUnbounded(t *testing.T) {
+	tree := makeRangeTree()
+	if tree.RangeCount(Bound[int]{}, Bound[int]{}) != 20 {
+		t.Fatalf("Zero bounds do not cover the whole tree")
+	}
+}
+
+func TestRangeInclusive(t *testing.T) {
+	tree := makeRangeTree()
+	values := slices.Collect(tree.Range(Inclusive(4), Inclusive(10)))
+	expected := []int{4, 6, 8, 10}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("Expected %v but got %v", expected, values)
+	}
+}
+
+func TestRangeExclusive(t *testing.T) {
+	tree := makeRangeTree()
+	values := slices.Collect(tree.Range(Exclusive(4), Exclusive(10)))
+	expected := []int{6, 8}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("Expected %v but got %v", expected, values)
+	}
+}
+
+func TestRangeBoundsBetweenValues(t *testing.T) {
+	tree := makeRangeTree()
+	values := slices.Collect(tree.Range(Exclusive(3), Inclusive(9)))
+	expected := []int{4, 6, 8}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("Expected %v but got %v", expected, values)
+	}
+}
+
+func TestRangeHalfOpen(t *testing.T) {
+	tree := makeRangeTree()
+	if count := tree.RangeCount(Inclusive(30), Unbounded[int]()); count != 5 {
+		t.Fatalf("Expected 5 values from 30 but got %d", count)
+	}
+	if count := tree.RangeCount(Unbounded[int](), Exclusive(6)); count != 3 {
+		t.Fatalf("Expected 3 values below 6 but got %d", count)
+	}
+}
+
+func TestRangeOutside(t *testing.T) {
+	tree := makeRangeTree()
+	if count := tree.RangeCount(Exclusive(38), Unbounded[int]()); count != 0 {
+		t.Fatalf("Expected no values above 38 but got %d", count)
+	}
+	if count := tree.RangeCount(Inclusive(10), Exclusive(10)); count != 0 {
+		t.Fatalf("Expected empty range but got %d", count)
+	}
+}
+
+func TestRangeBreak(t *testing.T) {
+	tree := makeRangeTree()
+	var values []int
+	for v := range tree.Range(Inclusive(10), Unbounded[int]()) {
+		if v > 14 {
+			break
+		}
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []int{10, 12, 14}) {
+		t.Fatalf("Expected [10 12 14] but got %v", values)
+	}
+}
+
+func TestNeighboursEmptyTree(t *testing.T) {
+	tree := MakeTree[int]()
+	if _, ok := tree.Floor(5); ok {
+		t.Fatalf("Empty tree has a floor")
+	}
+	if _, ok := tree.Ceiling(5); ok {
+		t.Fatalf("Empty tree has a ceiling")
+	}
+	if _, ok := tree.Lower(5); ok {
+		t.Fatalf("Empty tree has a lower value")
+	}
+	if _, ok := tree.Higher(5); ok {
+		t.Fatalf("Empty tree has a higher value")
+	}
+}
+
+func TestFloor(t *testing.T) {
+	tree := makeRangeTree()
+	if v, ok := tree.Floor(6); !ok || v != 6 {
+		t.Fatalf("Expected floor 6 but got %d", v)
+	}
+	if v, ok := tree.Floor(7); !ok || v != 6 {
+		t.Fatalf("Expected floor 6 but got %d", v)
+	}
+	if v, ok := tree.Floor(100); !ok || v != 38 {
+		t.Fatalf("Expected floor 38 but got %d", v)
+	}
+	if _, ok := tree.Floor(-1); ok {
+		t.Fatalf("Expected no floor for -1")
+	}
+}
+
+func TestCeiling(t *testing.T) {
+	tree := makeRangeTree()
+	if v, ok := tree.Ceiling(6); !ok || v != 6 {
+		t.Fatalf("Expected ceiling 6 but got %d", v)
+	}
+	if v, ok := tree.Ceiling(7); !ok || v != 8 {
+		t.Fatalf("Expected ceiling 8 but got %d", v)
+	}
+	if v, ok := tree.Ceiling(-1); !ok || v != 0 {
+		t.Fatalf("Expected ceiling 0 but got %d", v)
+	}
+	if _, ok := tree.Ceiling(39); ok {
+		t.Fatalf("Expected no ceiling for 39")
+	}
+}
+
+func TestLower(t *testing.T) {
+	tree := makeRangeTree()
+	if v, ok := tree.Lower(6); !ok || v != 4 {
+		t.Fatalf("Expected lower 4 but got %d", v)
+	}
+	if v, ok := tree.Lower(7); !ok || v != 6 {
+		t.Fatalf("Expected lower 6 but got %d", v)
+	}
+	if _, ok := tree.Lower(0); ok {
+		t.Fatalf("Expected no lower value for 0")
+	}
+}
+
+func TestHigher(t *testing.T) {
+	tree := makeRangeTree()
+	if v, ok := tree.Higher(6); !ok || v != 8 {
+		t.Fatalf("Expected higher 8 but got %d", v)
+	}
+	if v, ok := tree.Higher(5); !ok || v != 6 {
+		t.Fatalf("Expected higher 6 but got %d", v)
+	}
+	if _, ok := tree.Higher(38); ok {
+		t.Fatalf("Expected no higher value for 38")
+	}
+}
+
+func TestRangeLooksUpBoundsWhenLoopStarts(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 9 {
+		tree.Insert(i)
+	}
+	r := tree.Range(Inclusive(2), Inclusive(5))
+	for i := 10; i < 20; i++ {
+		tree.Insert(i)
+	}
+	tree.Delete(2)
+
+	values := slices.Collect(r)
+	expected := []int{3, 4, 5}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("Expected %v but got %v", expected, values)
+	}
+}
@@ -0,0 +1,49 @@
+package redblack
+
+// Returns the number of values in the tree that are smaller than v
+func (t Tree[V]) Rank(v V) int {
+	return t.index(t.seek(v).ceiling())
+}
+
+// Returns the k-th smallest value in the tree.
+//
+// The index k is zero-based, which means Select(0) returns the
+// smallest value and Select(Size() - 1) the biggest one.
+func (t Tree[V]) Select(k int) (V, bool) {
+	return t.node.nth(k).value()
+}
+
+// Returns the number of nodes in the tree that are smaller than n.
+//
+// If n is nil, it is considered to be past the biggest value.
+func (t rbtree[V, D]) index(n *node[V, D]) int {
+	if n == nil {
+		return t.node.subtreeSize()
+	}
+
+	i := n.left.subtreeSize()
+	for ; n.p != nil; n = n.p {
+		if n.p.right == n {
+			i += n.p.left.subtreeSize() + 1
+		}
+	}
+	return i
+}
+
+// Returns the node with the k-th smallest value in this subtree
+func (n *node[V, D]) nth(k int) *node[V, D] {
+	if k < 0 || k >= n.subtreeSize() {
+		return nil
+	}
+	for {
+		left := n.left.subtreeSize()
+		if k < left {
+			n = n.left
+		} else if k > left {
+			k -= left + 1
+			n = n.right
+		} else {
+			return n
+		}
+	}
+}
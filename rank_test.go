@@ -0,0 +1,69 @@
+package redblack
+
+import (
+	"testing"
+)
+
+func TestRankEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	if tree.Rank(5) != 0 {
+		t.Fatalf("Empty tree has rank %d", tree.Rank(5))
+	}
+	if _, ok := tree.Select(0); ok {
+		t.Fatalf("Selected value from empty tree")
+	}
+}
+
+func TestRank(t *testing.T) {
+	tree := makeRangeTree()
+	for i := range 20 {
+		if rank := tree.Rank(i * 2); rank != i {
+			t.Fatalf("Expected rank %d for %d but got %d", i, i*2, rank)
+		}
+		if rank := tree.Rank(i*2 + 1); rank != i+1 {
+			t.Fatalf("Expected rank %d for %d but got %d", i+1, i*2+1, rank)
+		}
+	}
+	if tree.Rank(-5) != 0 {
+		t.Fatalf("Value below minimum does not have rank 0")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tree := makeRangeTree()
+	for i := range 20 {
+		if v, ok := tree.Select(i); !ok || v != i*2 {
+			t.Fatalf("Expected %d at index %d but got %d", i*2, i, v)
+		}
+	}
+	if _, ok := tree.Select(-1); ok {
+		t.Fatalf("Selected value at negative index")
+	}
+	if _, ok := tree.Select(20); ok {
+		t.Fatalf("Selected value beyond size")
+	}
+}
+
+func TestRankSelectAfterDelete(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 1000 {
+		tree.Insert(i)
+	}
+	for i := 0; i < 1000; i += 2 {
+		tree.Delete(i)
+	}
+
+	validateSizes(t, tree.node)
+	if tree.Size() != 500 {
+		t.Fatalf("Expected size 500 but got %d", tree.Size())
+	}
+	for i := range 500 {
+		v, ok := tree.Select(i)
+		if !ok || v != i*2+1 {
+			t.Fatalf("Expected %d at index %d but got %d", i*2+1, i, v)
+		}
+		if tree.Rank(v) != i {
+			t.Fatalf("Expected rank %d for %d but got %d", i, v, tree.Rank(v))
+		}
+	}
+}
@@ -17,6 +17,18 @@
 //   - Scenario 3: Uncle is black (trinagle) --> rotate parent in opposite direction
 //   - Scenario 4: Uncle is black (line) --> rotate grandparent in opposite direction and recolor original parent and grandparent
 //
+// Deletion:
+//   - Node with two children --> swap places with successor, which has at most one child
+//   - Node with one child --> replace node with (red) child and color it black
+//   - Red leaf --> remove
+//   - Black leaf --> fix double black, then remove
+//
+// Fix double black:
+//   - Case 1: Sibling is red --> recolor sibling and parent, rotate parent towards node
+//   - Case 2: Sibling is black with black children --> color sibling red, move problem to parent
+//   - Case 3: Sibling is black, near nephew red --> recolor and rotate sibling away from node
+//   - Case 4: Sibling is black, far nephew red --> rotate parent towards node and recolor
+//
 // [RedBlack]: https://en.wikipedia.org/wiki/Red%E2%80%93black_tree
 package redblack
 
@@ -25,11 +37,11 @@ import (
 	"fmt"
 )
 
-type color int
+type Color int
 
 const (
-	black color = 0
-	red   color = 1
+	Black Color = 0
+	Red   Color = 1
 )
 
 type relationship int
@@ -39,8 +51,15 @@ const (
 	line     relationship = 1
 )
 
-func (c color) String() string {
-	if c == black {
+func (r relationship) String() string {
+	if r == triangle {
+		return "triangle"
+	}
+	return "line"
+}
+
+func (c Color) String() string {
+	if c == Black {
 		return "black"
 	} else {
 		return "red"
@@ -52,12 +71,13 @@ type Value interface {
 	cmp.Ordered
 }
 
-func show[V Value](v V) string {
+func show[V any](v V) string {
 	return fmt.Sprintf("%v", v)
 }
 
+// A Red-Black Tree holding a set of values
 type Tree[V Value] struct {
-	node *node[V]
+	rbtree[V, struct{}]
 }
 
 // MakeTree creates a new Red-Black Tree
@@ -65,24 +85,200 @@ func MakeTree[V Value]() Tree[V] {
 	return Tree[V]{}
 }
 
-type node[V Value] struct {
+// The balancing core that is shared by [Tree], [TreeFunc] and [Map].
+//
+// Every node is ordered by its value and carries some
+// additional data, which is not considered for the ordering.
+// The core never compares values itself, but operates on
+// positions that were looked up with [seek] or [seekFunc].
+type rbtree[V any, D any] struct {
+	node     *node[V, D]
+	observer Observer[V]
+}
+
+type node[V any, D any] struct {
 	val   V
-	color color
-	p     *node[V]
-	left  *node[V]
-	right *node[V]
+	data  D
+	color Color
+	size  int // number of nodes in this subtree
+	p     *node[V, D]
+	left  *node[V, D]
+	right *node[V, D]
 }
 
 // Insert a value into a the tree.
 //
 // If the value already exists, nothing happens
 func (t *Tree[V]) Insert(v V) {
-	if t.node == nil {
-		t.node = &node[V]{val: v, color: black}
+	t.insert(seek(t.node, v), v, struct{}{})
+}
+
+// Delete a value from the tree.
+//
+// Returns whether the value was in the tree
+func (t *Tree[V]) Delete(v V) bool {
+	return t.delete(seek(t.node, v))
+}
+
+// Returns the smallest value in the tree
+func (t Tree[V]) Min() (V, bool) {
+	return t.node.min().value()
+}
+
+// Returns the biggest value in the tree
+func (t Tree[V]) Max() (V, bool) {
+	return t.node.max().value()
+}
+
+// Removes and returns the smallest value in the tree
+func (t *Tree[V]) PopMin() (V, bool) {
+	return t.pop(t.node.min())
+}
+
+// Removes and returns the biggest value in the tree
+func (t *Tree[V]) PopMax() (V, bool) {
+	return t.pop(t.node.max())
+}
+
+// Inserts a value with the attached data at the position
+// where it was looked up.
+//
+// If the value already exists, nothing is inserted and the
+// existing node is returned. Otherwise, the result is nil.
+func (t *rbtree[V, D]) insert(pos position[V, D], v V, d D) *node[V, D] {
+	if pos.match != nil {
+		return pos.match
+	}
+
+	n := t.link(pos, v, d)
+	t.fixViolations(n, nil)
+
+	t.recolor(t.node, Black)
+	return nil
+}
+
+// Adds a new red leaf at the position without restoring the properties
+func (t *rbtree[V, D]) link(pos position[V, D], v V, d D) *node[V, D] {
+	n := &node[V, D]{val: v, data: d, color: Red, size: 1, p: pos.parent}
+	if pos.parent == nil {
+		t.node = n
+	} else if pos.less {
+		pos.parent.left = n
+	} else {
+		pos.parent.right = n
+	}
+	n.resizeAncestors(1)
+	if t.observer != nil {
+		t.observer.OnInsert(v)
+	}
+	return n
+}
+
+// Deletes the value at the position where it was looked up.
+//
+// Returns whether the value was in the tree
+func (t *rbtree[V, D]) delete(pos position[V, D]) bool {
+	if pos.match == nil {
+		return false
+	}
+	t.remove(pos.match)
+	return true
+}
+
+// Removes the node and returns its value
+func (t *rbtree[V, D]) pop(n *node[V, D]) (V, bool) {
+	v, ok := n.value()
+	if ok {
+		t.remove(n)
+	}
+	return v, ok
+}
+
+// Removes the node from the tree and restores the properties
+func (t *rbtree[V, D]) remove(n *node[V, D]) {
+	if t.observer != nil {
+		t.observer.OnDelete(n.val)
+	}
+	if n.left != nil && n.right != nil {
+		t.swap(n, n.right.min())
+	}
+
+	// n has at most one child now, which means it is the
+	// node that is actually removed from the tree
+	n.resizeAncestors(-1)
+	n.size = 0
+
+	child := n.left
+	if child == nil {
+		child = n.right
+	}
+
+	if child != nil {
+		// a single child is always red, otherwise the
+		// black height of both sides would differ
+		t.recolor(child, Black)
+		t.replace(n, child)
+	} else if n.p == nil {
+		t.node = nil
 	} else {
-		t.node.insert(v)
+		if n.color == Black {
+			t.fixDoubleBlack(n)
+		}
+		t.replace(n, nil)
 	}
-	t.node.color = black
+}
+
+// Swaps the positions of n and its successor s in the tree, so
+// that n has at most one child. The colors and sizes stay where
+// they are, since they belong to the position and not the value.
+// This is not rebalancing, so the observer is not notified.
+func (t *rbtree[V, D]) swap(n *node[V, D], s *node[V, D]) {
+	n.color, s.color = s.color, n.color
+	n.size, s.size = s.size, n.size
+
+	left, right := n.left, n.right
+	sp, sr := s.p, s.right
+
+	t.replace(n, s)
+	s.left = left
+	left.p = s
+	if sp == n {
+		s.right = n
+		n.p = s
+	} else {
+		s.right = right
+		right.p = s
+		sp.left = n
+		n.p = sp
+	}
+
+	n.left = nil
+	n.right = sr
+	if sr != nil {
+		sr.p = n
+	}
+}
+
+// replace n with the specified child (which may be nil) in n's parent
+func (t *rbtree[V, D]) replace(n *node[V, D], child *node[V, D]) {
+	if child != nil {
+		child.p = n.p
+	}
+	if n.p == nil {
+		t.node = child
+	} else if n.p.left == n {
+		n.p.left = child
+	} else {
+		n.p.right = child
+	}
+}
+
+// Returns a deep copy of the tree.
+//
+// Copying a Tree value only copies the reference to the root,
+// which means both copies would share (and modify) the same nodes.
+func (t Tree[V]) Clone() Tree[V] {
+	return Tree[V]{rbtree[V, struct{}]{node: t.node.clone(nil)}}
 }
 
 // Formats the string in a human readable format
@@ -96,20 +292,24 @@ func (t Tree[V]) String() string {
 
 // Format the tree with JSON
 //
-// This can be put into a [JSONVisualizer] for debug purposes
+// This can be put into a [JSONVisualizer] for debug purposes, or see
+// [WriteAnimation] for an offline page.
+// The values are encoded with encoding/json. If a value cannot
+// be encoded (e.g. NaN), the result is empty. Use [Tree.Structural]
+// to get the error and to decode the format again.
 //
 // [JSONVisualizer]: https://vanya.jp.net/vtree/
 func (t Tree[V]) Json() string {
-	if t.node == nil {
-		return "{}"
-	} else {
-		return t.node.json()
+	acc, err := t.node.json()
+	if err != nil {
+		return ""
 	}
+	return acc
 }
 
 // Checks whether the specified value is in the tree
 func (t Tree[V]) Contains(v V) bool {
-	return t.node.contains(v)
+	return seek(t.node, v).match != nil
 }
 
 // Returns the height of the tree
@@ -126,157 +326,269 @@ func (t Tree[V]) Height() int {
 }
 
 // Returns the total number of nodes in the tree
+//
+// Every node keeps the size of its subtree, so this is O(1)
 func (t Tree[V]) Size() int {
-	return t.node.size()
+	return t.node.subtreeSize()
 }
 
-func (n *node[V]) insert(v V) {
-	if v == n.val {
-		return
-	} else if v > n.val {
-		if n.right == nil {
-			n.right = &node[V]{val: v, color: red, p: n}
-			n.right.fixViolations()
-		} else {
-			n.right.insert(v)
+// Restores the properties after n has been inserted as a red node.
+//
+// If trace is not nil, it is called after every scenario.
+func (t *rbtree[V, D]) fixViolations(n *node[V, D], trace func(Step[V])) {
+	for n.p != nil && n.p.p != nil && n.p.color == Red {
+		uncle, rel, ok := n.uncle()
+		p, g := n.p, n.p.p
+
+		// leaves are black, so no uncle means black
+		if ok && uncle.color == Red {
+			// scenario 2
+			t.recolor(p, Black)
+			t.recolor(g, Red)
+			t.recolor(uncle, Black)
+			if trace != nil {
+				step := n.step(2)
+				step.Recolored = []V{p.val, g.val, uncle.val}
+				trace(step)
+			}
+			n = g
+			continue
 		}
-	} else {
-		if n.left == nil {
-			n.left = &node[V]{val: v, color: red, p: n}
-			n.left.fixViolations()
-		} else {
-			n.left.insert(v)
+
+		if rel == triangle {
+			// scenario 3: turn the triangle into a line
+			var step Step[V]
+			if trace != nil {
+				step = n.step(3)
+			}
+			dir := RotateLeft
+			if p.left == n {
+				dir = RotateRight
+			}
+			t.rotate(p, dir)
+			if trace != nil {
+				step.Rotations = []Rotation[V]{{dir, p.val}}
+				trace(step)
+			}
+			n, p = p, n
 		}
-	}
-}
 
-func (n *node[V]) fixViolations() {
-	if n.p == nil || n.p.p == nil || n.p.color != red {
+		// scenario 4
+		var step Step[V]
+		if trace != nil {
+			step = n.step(4)
+		}
+		t.recolor(p, Black)
+		t.recolor(g, Red)
+		dir := RotateLeft
+		if p.left == n {
+			dir = RotateRight
+		}
+		t.rotate(g, dir)
+		if trace != nil {
+			step.Recolored = []V{p.val, g.val}
+			step.Rotations = []Rotation[V]{{dir, g.val}}
+			trace(step)
+		}
 		return
 	}
+}
 
-	uncle, rel, ok := n.uncle()
-
-	// leaves are black, so no uncle means black
-	redUncle := ok && uncle.color == red
-
-	if redUncle {
-		n.p.recolor()
-		n.p.p.recolor()
-		uncle.color = black
-		n.p.p.fixViolations()
-	} else {
-		var rotateNode *node[V]
-		if rel == triangle {
-			rotateNode = n.p
+// Fixes the missing black node on the path through n.
+//
+// The node itself is not removed, which means the caller can
+// detach it after the properties have been restored.
+func (t *rbtree[V, D]) fixDoubleBlack(n *node[V, D]) {
+	for n.p != nil && n.color == Black {
+		p := n.p
+		if p.left == n {
+			sibling := p.right
+			if sibling.color == Red {
+				// case 1
+				t.recolor(sibling, Black)
+				t.recolor(p, Red)
+				t.leftRotate(p)
+				continue
+			}
+			if sibling.left.isBlack() && sibling.right.isBlack() {
+				// case 2
+				t.recolor(sibling, Red)
+				n = p
+				continue
+			}
+			if sibling.right.isBlack() {
+				// case 3
+				t.recolor(sibling.left, Black)
+				t.recolor(sibling, Red)
+				t.rightRotate(sibling)
+				sibling = p.right
+			}
+			// case 4
+			t.recolor(sibling, p.color)
+			t.recolor(p, Black)
+			t.recolor(sibling.right, Black)
+			t.leftRotate(p)
+			return
 		} else {
-			rotateNode = n.p.p
-			n.p.recolor()
-			n.p.p.recolor()
+			sibling := p.left
+			if sibling.color == Red {
+				// case 1
+				t.recolor(sibling, Black)
+				t.recolor(p, Red)
+				t.rightRotate(p)
+				continue
+			}
+			if sibling.left.isBlack() && sibling.right.isBlack() {
+				// case 2
+				t.recolor(sibling, Red)
+				n = p
+				continue
+			}
+			if sibling.left.isBlack() {
+				// case 3
+				t.recolor(sibling.right, Black)
+				t.recolor(sibling, Red)
+				t.leftRotate(sibling)
+				sibling = p.left
+			}
+			// case 4
+			t.recolor(sibling, p.color)
+			t.recolor(p, Black)
+			t.recolor(sibling.left, Black)
+			t.rightRotate(p)
+			return
 		}
+	}
+	t.recolor(n, Black)
+}
 
-		if n.p.left == n {
-			rotateNode.rightRotate()
-			rotateNode.right.fixViolations()
-		} else {
-			rotateNode.leftRotate()
-			rotateNode.left.fixViolations()
-		}
+// leaves are black, so nil is black
+func (n *node[V, D]) isBlack() bool {
+	return n == nil || n.color == Black
+}
+
+// Changes the color of n and tells the observer about it
+func (t *rbtree[V, D]) recolor(n *node[V, D], c Color) {
+	if n.color == c {
+		return
 	}
+	if t.observer != nil {
+		t.observer.OnRecolor(n.val, n.color, c)
+	}
+	n.color = c
 }
 
-func (n *node[V]) recolor() {
-	if n.color == black {
-		n.color = red
+func (t *rbtree[V, D]) rotate(n *node[V, D], dir Direction) {
+	if dir == RotateLeft {
+		t.leftRotate(n)
 	} else {
-		n.color = black
+		t.rightRotate(n)
 	}
 }
 
-func (n *node[V]) leftRotate() {
-	if n.right == nil {
+// Moves the right child of n into the place of n and makes n its
+// left child. Only the links change, so all nodes keep their values.
+func (t *rbtree[V, D]) leftRotate(n *node[V, D]) {
+	r := n.right
+	if r == nil {
 		panic("Can't left-rotate if I don't have a right child")
 	}
-
-	var newLeftChild node[V] = *n // store old n, becomes left child
-	*n = *n.right                 // right child becomes the parent
-
-	n.p = newLeftChild.p
-	newLeftChild.p = n
-
-	if n.left == nil {
-		newLeftChild.right = nil
-	} else {
-		*newLeftChild.right = *n.left
-		if newLeftChild.right.left != nil {
-			newLeftChild.right.left.p = newLeftChild.right
-		}
-		if newLeftChild.right.right != nil {
-			newLeftChild.right.right.p = newLeftChild.right
-		}
-		newLeftChild.right.p = &newLeftChild
-	}
-	n.left = &newLeftChild
-	if n.right != nil {
-		n.right.p = n
+	if t.observer != nil {
+		t.observer.OnRotate(RotateLeft, n.val)
 	}
 
-	if newLeftChild.left != nil {
-		newLeftChild.left.p = &newLeftChild
+	n.right = r.left
+	if r.left != nil {
+		r.left.p = n
 	}
+	t.replace(n, r)
+	r.left = n
+	n.p = r
+
+	r.size = n.size
+	n.updateSize()
 }
 
-func (n *node[V]) rightRotate() {
-	if n.left == nil {
+// Moves the left child of n into the place of n and makes n its
+// right child. Only the links change, so all nodes keep their values.
+func (t *rbtree[V, D]) rightRotate(n *node[V, D]) {
+	l := n.left
+	if l == nil {
 		panic("Can't right-rotate if I don't have a left child")
 	}
+	if t.observer != nil {
+		t.observer.OnRotate(RotateRight, n.val)
+	}
 
-	var newRightChild node[V] = *n // store old n, becomes right child
-	*n = *n.left                   // left child becomes the parent
+	n.left = l.right
+	if l.right != nil {
+		l.right.p = n
+	}
+	t.replace(n, l)
+	l.right = n
+	n.p = l
 
-	n.p = newRightChild.p
-	newRightChild.p = n
+	l.size = n.size
+	n.updateSize()
+}
 
-	if n.right == nil {
-		newRightChild.left = nil
-	} else {
-		*newRightChild.left = *n.right
-		if newRightChild.left.left != nil {
-			newRightChild.left.left.p = newRightChild.left
-		}
-		if newRightChild.left.right != nil {
-			newRightChild.left.right.p = newRightChild.left
-		}
-		newRightChild.left.p = &newRightChild
-	}
-	n.right = &newRightChild
-	if n.left != nil {
-		n.left.p = n
+// Copies this subtree and attaches it to the parent
+func (n *node[V, D]) clone(parent *node[V, D]) *node[V, D] {
+	if n == nil {
+		return nil
 	}
+	c := *n
+	c.p = parent
+	c.left = n.left.clone(&c)
+	c.right = n.right.clone(&c)
+	return &c
+}
 
-	if newRightChild.right != nil {
-		newRightChild.right.p = &newRightChild
+// Returns the node with the smallest value in this subtree
+func (n *node[V, D]) min() *node[V, D] {
+	if n == nil {
+		return nil
 	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
 }
 
-func (n *node[V]) contains(v V) bool {
+// Returns the node with the biggest value in this subtree
+func (n *node[V, D]) max() *node[V, D] {
 	if n == nil {
-		return false
+		return nil
 	}
-
-	if n.val == v {
-		return true
+	for n.right != nil {
+		n = n.right
 	}
+	return n
+}
 
-	if v > n.val {
-		return n.right.contains(v)
-	} else {
-		return n.left.contains(v)
+// Returns the node with the next bigger value or nil if there is none
+func (n *node[V, D]) next() *node[V, D] {
+	if n.right != nil {
+		return n.right.min()
+	}
+	for n.p != nil && n.p.right == n {
+		n = n.p
 	}
+	return n.p
+}
 
+// Returns the node with the next smaller value or nil if there is none
+func (n *node[V, D]) prev() *node[V, D] {
+	if n.left != nil {
+		return n.left.max()
+	}
+	for n.p != nil && n.p.left == n {
+		n = n.p
+	}
+	return n.p
 }
 
-func (n *node[V]) String() string {
+func (n *node[V, D]) String() string {
 	if n == nil {
 		return ""
 	}
@@ -292,32 +604,7 @@ func (n *node[V]) String() string {
 	return acc
 }
 
-func (n *node[V]) json() string {
-	if n == nil {
-		return "{}"
-	}
-
-	var parent string
-	if n.p == nil {
-		parent = "\"nil\""
-	} else {
-		parent = show(n.p.val)
-		// parent = fmt.Sprintf("\"%p\"", n.p)
-	}
-
-	acc := "{"
-	acc += "\"value\": " + show(n.val) + ","
-	// acc += "\"ref\": \"" + fmt.Sprintf("%p", n) + "\","
-	acc += "\"color\": \"" + n.color.String() + "\","
-	acc += "\"parent\": " + parent + ","
-	acc += "\"left\": " + n.left.json() + ","
-	acc += "\"right\": " + n.right.json()
-	acc += "}"
-
-	return acc
-}
-
-func (n *node[V]) height(depth int) int {
+func (n *node[V, D]) height(depth int) int {
 	if n == nil {
 		return depth
 	}
@@ -331,18 +618,27 @@ func (n *node[V]) height(depth int) int {
 	}
 }
 
-func (n *node[V]) size() int {
+// Returns the number of nodes in this subtree
+func (n *node[V, D]) subtreeSize() int {
 	if n == nil {
 		return 0
 	}
+	return n.size
+}
 
-	l := n.left.size()
-	r := n.right.size()
+// Recomputes the size of n from its children
+func (n *node[V, D]) updateSize() {
+	n.size = n.left.subtreeSize() + n.right.subtreeSize() + 1
+}
 
-	return l + r + 1
+// Adds delta to the size of all ancestors of n
+func (n *node[V, D]) resizeAncestors(delta int) {
+	for p := n.p; p != nil; p = p.p {
+		p.size += delta
+	}
 }
 
-func (n *node[V]) uncle() (*node[V], relationship, bool) {
+func (n *node[V, D]) uncle() (*node[V, D], relationship, bool) {
 	parent := n.p
 	if parent == nil {
 		return nil, 0, false
@@ -362,7 +658,7 @@ func (n *node[V]) uncle() (*node[V], relationship, bool) {
 		rel = triangle
 	}
 
-	var uncle *node[V]
+	var uncle *node[V, D]
 	if grandparent.left == parent {
 		uncle = grandparent.right
 	} else {
@@ -1,8 +1,8 @@
 package redblack
 
 import (
-	"cmp"
 	"fmt"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -127,6 +127,298 @@ func TestInsertManyContainsAll(t *testing.T) {
 	}
 	validateTreeProperties(t, tree.node)
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+}
+
+func TestDeleteEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	if tree.Delete(5) {
+		t.Fatalf("Deleted 5 from empty tree")
+	}
+}
+
+func TestDeleteMissing(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(3)
+	if tree.Delete(4) {
+		t.Fatalf("Deleted 4 which is not in the tree")
+	}
+	if tree.Size() != 2 {
+		t.Fatalf("Tree has not size 2")
+	}
+}
+
+func TestDeleteRoot(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	if !tree.Delete(5) {
+		t.Fatalf("Did not delete 5")
+	}
+	if tree.Contains(5) {
+		t.Fatalf("Tree still contains 5")
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Tree has not size 0")
+	}
+	if tree.String() != "EmptyTree" {
+		t.Fatalf("Tree is not empty but '%s'", tree.String())
+	}
+}
+
+func TestDeleteRootWithChildren(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(3)
+	tree.Insert(7)
+	tree.Delete(5)
+
+	if tree.node.val != 7 {
+		t.Fatalf("Successor 7 did not become root")
+	}
+	if tree.Contains(5) {
+		t.Fatalf("Tree still contains 5")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestDeleteRedLeaf(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(3)
+	tree.Delete(3)
+
+	if tree.node.left != nil {
+		t.Fatalf("Left child was not removed")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestDeleteBlackNodeWithRedChild(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{5, 3, 7, 1}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+	tree.Delete(3)
+
+	if tree.node.left.val != 1 {
+		t.Fatalf("1 did not replace 3")
+	}
+	if tree.node.left.color != Black {
+		t.Fatalf("1 was not colored black")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestDeleteRedSibling(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{2, 1, 4, 3, 5, 6}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+	tree.Delete(6)
+
+	if tree.node.right.color != Red {
+		t.Fatalf("Precondition: sibling 4 should be red")
+	}
+
+	tree.Delete(1)
+
+	if tree.node.val != 4 {
+		t.Fatalf("Sibling 4 did not become root")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestDeleteBlackSiblingBlackChildren(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{2, 1, 3, 4}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+	tree.Delete(4)
+	tree.Delete(1)
+
+	if tree.node.right.color != Red {
+		t.Fatalf("Sibling 3 was not colored red")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestDeleteNearNephewRed(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{2, 1, 4, 3}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+	tree.Delete(1)
+
+	if tree.node.val != 3 {
+		t.Fatalf("Near nephew 3 did not become root")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestDeleteFarNephewRed(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{2, 1, 4, 5}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+	tree.Delete(1)
+
+	if tree.node.val != 4 {
+		t.Fatalf("Sibling 4 did not become root")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+}
+
+func TestDeleteAll(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{10, 3, 15, 1, 2, 100, 4, 17, 16, 9, 75, 8, 11, 12, 33, 20, 5, 6, 7, 22, 13, 14, 88, 18, 19}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+	for i := range numbers {
+		if !tree.Delete(numbers[i]) {
+			t.Fatalf("Did not delete %d", numbers[i])
+		}
+		if tree.Contains(numbers[i]) {
+			t.Fatalf("Tree still contains %d", numbers[i])
+		}
+		if tree.Size() != len(numbers)-i-1 {
+			t.Fatalf("Tree does not have size %d", len(numbers)-i-1)
+		}
+		for j := i + 1; j < len(numbers); j++ {
+			if !tree.Contains(numbers[j]) {
+				t.Fatalf("Tree does not contain %d anymore", numbers[j])
+			}
+		}
+		validateTreeProperties(t, tree.node)
+		validateParentRefs(t, tree.node)
+		validateSizes(t, tree.node)
+	}
+}
+
+func TestDeleteBig(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 10000 {
+		tree.Insert(i)
+	}
+	for i := 0; i < 10000; i += 3 {
+		tree.Delete(i)
+	}
+	for i := 9999; i >= 0; i -= 7 {
+		tree.Delete(i)
+	}
+
+	for i := range 10000 {
+		expected := i%3 != 0 && (9999-i)%7 != 0
+		if tree.Contains(i) != expected {
+			t.Fatalf("Expected Contains(%d) to be %v", i, expected)
+		}
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+}
+
+func TestMinMaxEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	if _, ok := tree.Min(); ok {
+		t.Fatalf("Empty tree has a minimum")
+	}
+	if _, ok := tree.Max(); ok {
+		t.Fatalf("Empty tree has a maximum")
+	}
+	if _, ok := tree.PopMin(); ok {
+		t.Fatalf("Popped minimum from empty tree")
+	}
+	if _, ok := tree.PopMax(); ok {
+		t.Fatalf("Popped maximum from empty tree")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tree := MakeTree[int]()
+	numbers := []int{10, 3, 15, 1, 2, 100, 4, 17}
+	for i := range numbers {
+		tree.Insert(numbers[i])
+	}
+	if v, ok := tree.Min(); !ok || v != 1 {
+		t.Fatalf("Expected minimum 1 but got %d", v)
+	}
+	if v, ok := tree.Max(); !ok || v != 100 {
+		t.Fatalf("Expected maximum 100 but got %d", v)
+	}
+	if tree.Size() != len(numbers) {
+		t.Fatalf("Min or Max changed the tree")
+	}
+}
+
+func TestPopMin(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 100 {
+		tree.Insert(99 - i)
+	}
+	for i := range 100 {
+		v, ok := tree.PopMin()
+		if !ok || v != i {
+			t.Fatalf("Expected minimum %d but got %d", i, v)
+		}
+		validateTreeProperties(t, tree.node)
+		validateParentRefs(t, tree.node)
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Tree is not empty after popping all values")
+	}
+}
+
+func TestPopMax(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 100 {
+		tree.Insert(i)
+	}
+	for i := range 100 {
+		v, ok := tree.PopMax()
+		if !ok || v != 99-i {
+			t.Fatalf("Expected maximum %d but got %d", 99-i, v)
+		}
+		validateTreeProperties(t, tree.node)
+		validateParentRefs(t, tree.node)
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Tree is not empty after popping all values")
+	}
+}
+
+func TestClone(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 100 {
+		tree.Insert(i)
+	}
+
+	clone := tree.Clone()
+	clone.Delete(50)
+	tree.Insert(100)
+
+	if !tree.Contains(50) || clone.Contains(100) {
+		t.Fatalf("Clone shares nodes with the original tree")
+	}
+	if clone.Size() != 99 || tree.Size() != 101 {
+		t.Fatalf("Expected sizes 99 and 101 but got %d and %d", clone.Size(), tree.Size())
+	}
+	validateTreeProperties(t, clone.node)
+	validateParentRefs(t, clone.node)
+	validateSizes(t, clone.node)
 }
 
 func TestStringEmpty(t *testing.T) {
@@ -180,7 +472,7 @@ func TestRightRotatePanicWithoutLeftChild(t *testing.T) {
 
 	}()
 
-	tree.node.rightRotate()
+	tree.rightRotate(tree.node)
 }
 
 func TestRightRotateWithoutRightGrandchild(t *testing.T) {
@@ -190,7 +482,7 @@ func TestRightRotateWithoutRightGrandchild(t *testing.T) {
 		tree.Insert(numbers[i])
 	}
 
-	tree.node.rightRotate()
+	tree.rightRotate(tree.node)
 
 	for i := range numbers {
 		if !tree.Contains(numbers[i]) {
@@ -201,6 +493,7 @@ func TestRightRotateWithoutRightGrandchild(t *testing.T) {
 		t.Fatalf("Tree does not have size %d", len(numbers))
 	}
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
 }
 
 func TestRightRotate(t *testing.T) {
@@ -210,7 +503,7 @@ func TestRightRotate(t *testing.T) {
 		tree.Insert(numbers[i])
 	}
 
-	tree.node.rightRotate()
+	tree.rightRotate(tree.node)
 
 	for i := range numbers {
 		if !tree.Contains(numbers[i]) {
@@ -221,6 +514,7 @@ func TestRightRotate(t *testing.T) {
 		t.Fatalf("Tree does not have size %d", len(numbers))
 	}
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
 }
 
 func TestRightRotateNoUncle(t *testing.T) {
@@ -230,7 +524,7 @@ func TestRightRotateNoUncle(t *testing.T) {
 		tree.Insert(numbers[i])
 	}
 
-	tree.node.rightRotate()
+	tree.rightRotate(tree.node)
 
 	for i := range numbers {
 		if !tree.Contains(numbers[i]) {
@@ -241,6 +535,7 @@ func TestRightRotateNoUncle(t *testing.T) {
 		t.Fatalf("Tree does not have size %d", len(numbers))
 	}
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
 }
 
 func TestLeftRotateLine(t *testing.T) {
@@ -273,7 +568,7 @@ func TestLeftRotateNoUncle(t *testing.T) {
 		tree.Insert(numbers[i])
 	}
 
-	tree.node.leftRotate()
+	tree.leftRotate(tree.node)
 
 	for i := range numbers {
 		if !tree.Contains(numbers[i]) {
@@ -284,6 +579,7 @@ func TestLeftRotateNoUncle(t *testing.T) {
 		t.Fatalf("Tree does not have size %d", len(numbers))
 	}
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
 }
 
 func TestLeftRotatePanicWithoutRightChild(t *testing.T) {
@@ -297,7 +593,7 @@ func TestLeftRotatePanicWithoutRightChild(t *testing.T) {
 		}
 	}()
 
-	tree.node.leftRotate()
+	tree.leftRotate(tree.node)
 }
 
 func TestLeftRotateWithoutLeftGrandchild(t *testing.T) {
@@ -307,7 +603,7 @@ func TestLeftRotateWithoutLeftGrandchild(t *testing.T) {
 		tree.Insert(numbers[i])
 	}
 
-	tree.node.leftRotate()
+	tree.leftRotate(tree.node)
 
 	for i := range numbers {
 		if !tree.Contains(numbers[i]) {
@@ -318,6 +614,7 @@ func TestLeftRotateWithoutLeftGrandchild(t *testing.T) {
 		t.Fatalf("Tree does not have size %d", len(numbers))
 	}
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
 }
 
 func TestLeftRotate(t *testing.T) {
@@ -327,7 +624,7 @@ func TestLeftRotate(t *testing.T) {
 		tree.Insert(numbers[i])
 	}
 
-	tree.node.leftRotate()
+	tree.leftRotate(tree.node)
 
 	for i := range numbers {
 		if !tree.Contains(numbers[i]) {
@@ -338,6 +635,47 @@ func TestLeftRotate(t *testing.T) {
 		t.Fatalf("Tree does not have size %d", len(numbers))
 	}
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+}
+
+func TestRotateKeepsNodes(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 7} {
+		tree.Insert(v)
+	}
+	root, left := tree.node, tree.node.left
+
+	tree.rightRotate(tree.node)
+
+	if tree.node != left || tree.node.right != root {
+		t.Fatalf("Rotation did not move the nodes")
+	}
+	if left.val != 3 || root.val != 5 {
+		t.Fatalf("Rotation changed the values of the nodes")
+	}
+}
+
+func TestInsertAndDeleteKeepNodes(t *testing.T) {
+	tree := MakeTree[int]()
+	nodes := map[int]*node[int, struct{}]{}
+	for i := range 500 {
+		v := (i * 37) % 500
+		tree.Insert(v)
+		nodes[v] = seek(tree.node, v).match
+	}
+	for v := 0; v < 500; v += 3 {
+		tree.Delete(v)
+		delete(nodes, v)
+	}
+
+	for v, n := range nodes {
+		if n.val != v || seek(tree.node, v).match != n {
+			t.Fatalf("Node of %d was replaced", v)
+		}
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
 }
 
 func TestParentRefsEmpty(t *testing.T) {
@@ -356,7 +694,7 @@ func TestParentRefsSmallTree(t *testing.T) {
 	}
 }
 
-func validateParentRefs[O cmp.Ordered](t *testing.T, n *node[O]) {
+func validateParentRefs[O any, D any](t *testing.T, n *node[O, D]) {
 	if n == nil {
 		return
 	}
@@ -377,6 +715,19 @@ func validateParentRefs[O cmp.Ordered](t *testing.T, n *node[O]) {
 	validateParentRefs(t, n.right)
 }
 
+func validateSizes[O any, D any](t *testing.T, n *node[O, D]) {
+	if n == nil {
+		return
+	}
+
+	if expected := n.left.subtreeSize() + n.right.subtreeSize() + 1; n.size != expected {
+		t.Fatalf("%s has size %d, but subtree has %d nodes", show(n.val), n.size, expected)
+	}
+
+	validateSizes(t, n.left)
+	validateSizes(t, n.right)
+}
+
 func TestUncleLeftTriangle(t *testing.T) {
 	tree := MakeTree[int]()
 	tree.Insert(2)
@@ -483,7 +834,7 @@ func TestNewInsertRootIsBlack(t *testing.T) {
 	tree := MakeTree[int]()
 	tree.Insert(1)
 
-	if tree.node.color != black {
+	if tree.node.color != Black {
 		t.Fatalf("root is not black")
 	}
 
@@ -495,23 +846,23 @@ func TestRecolorUncleRed(t *testing.T) {
 	tree.Insert(1)
 	tree.Insert(4)
 
-	if tree.node.right.color != red {
+	if tree.node.right.color != Red {
 		t.Fatalf("Precondition: right child should be red")
 
 	}
-	if tree.node.left.color != red {
+	if tree.node.left.color != Red {
 		t.Fatalf("Precondition: left child should be red")
 	}
 
 	tree.Insert(2)
 
-	if tree.node.right.color != black {
+	if tree.node.right.color != Black {
 		t.Fatalf("Uncle was not recolored")
 	}
-	if tree.node.left.color != black {
+	if tree.node.left.color != Black {
 		t.Fatalf("Parent was not recolored")
 	}
-	if tree.node.color != black {
+	if tree.node.color != Black {
 		t.Fatalf("Grandparent was not recolored")
 	}
 	validateTreeProperties(t, tree.node)
@@ -523,21 +874,21 @@ func TestRotateLineLeft(t *testing.T) {
 	tree.Insert(5)
 	tree.Insert(2)
 
-	tree.node.right.color = black
-	tree.node.left.color = red
+	tree.node.right.color = Black
+	tree.node.left.color = Red
 
 	tree.Insert(1)
 
 	if tree.node.val != 2 {
 		t.Fatalf("2 is not root")
 	}
-	if tree.node.color != black {
+	if tree.node.color != Black {
 		t.Fatalf("parent not recolored")
 	}
 	if tree.node.right.val != 4 {
 		t.Fatalf("4 is not right child")
 	}
-	if tree.node.right.color != red {
+	if tree.node.right.color != Red {
 		t.Fatalf("Grandparent not recolored")
 	}
 	if tree.node.left.val != 1 {
@@ -552,21 +903,21 @@ func TestRotateLineRight(t *testing.T) {
 	tree.Insert(1)
 	tree.Insert(3)
 
-	tree.node.left.color = black
-	tree.node.right.color = red
+	tree.node.left.color = Black
+	tree.node.right.color = Red
 
 	tree.Insert(4)
 
 	if tree.node.val != 3 {
 		t.Fatalf("3 is not root")
 	}
-	if tree.node.color != black {
+	if tree.node.color != Black {
 		t.Fatalf("Parent is not black")
 	}
 	if tree.node.left.val != 2 {
 		t.Fatalf("2 is not left child")
 	}
-	if tree.node.left.color != red {
+	if tree.node.left.color != Red {
 		t.Fatalf("Grandparent is not red")
 	}
 	if tree.node.right.val != 4 {
@@ -591,6 +942,7 @@ func TestValidateTreePropertiesIncreasing(t *testing.T) {
 	}
 	validateTreeProperties(t, tree.node)
 	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
 }
 
 func TestValidateTreePropertiesDecreasing(t *testing.T) {
@@ -620,13 +972,13 @@ func TestValidateTreePropertiesBig(t *testing.T) {
 	validateTreeProperties(t, tree.node)
 }
 
-func validateTreeProperties[O cmp.Ordered](t *testing.T, n *node[O]) {
+func validateTreeProperties[O any, D any](t *testing.T, n *node[O, D]) {
 	if n == nil {
 		return
 
 	}
 
-	if n.color != black {
+	if n.color != Black {
 		t.Fatalf("Root is not black")
 	}
 
@@ -640,16 +992,16 @@ func validateTreeProperties[O cmp.Ordered](t *testing.T, n *node[O]) {
 
 }
 
-func validateRedNodeHasBlackChildren[O cmp.Ordered](n *node[O]) error {
+func validateRedNodeHasBlackChildren[O any, D any](n *node[O, D]) error {
 	if n == nil {
 		return nil
 	}
 
-	if n.color == red {
-		if n.left != nil && n.left.color != black {
+	if n.color == Red {
+		if n.left != nil && n.left.color != Black {
 			return fmt.Errorf("Red node %s has red left child %s", show(n.val), show(n.left.val))
 		}
-		if n.right != nil && n.right.color != black {
+		if n.right != nil && n.right.color != Black {
 			return fmt.Errorf("Red node %s has red right child %s", show(n.val), show(n.right.val))
 		}
 	}
@@ -665,7 +1017,7 @@ func validateRedNodeHasBlackChildren[O cmp.Ordered](n *node[O]) error {
 	return nil
 }
 
-func validateSameNumberOfBlackNodesToLeaves[O cmp.Ordered](n *node[O]) (int, error) {
+func validateSameNumberOfBlackNodesToLeaves[O any, D any](n *node[O, D]) (int, error) {
 	if n == nil {
 		return 0, nil
 	}
@@ -685,10 +1037,53 @@ func validateSameNumberOfBlackNodesToLeaves[O cmp.Ordered](n *node[O]) (int, err
 	}
 
 	self := 0
-	if n.color == black {
+	if n.color == Black {
 		self = 1
 	}
 
 	return left + self, nil
 
 }
+
+func benchmarkValues(n int) []int {
+	r := rand.New(rand.NewPCG(1, 2))
+	return r.Perm(n)
+}
+
+func benchmarkInsert(b *testing.B, values []int) {
+	b.ReportAllocs()
+	for range b.N {
+		tree := MakeTree[int]()
+		for _, v := range values {
+			tree.Insert(v)
+		}
+	}
+}
+
+func BenchmarkInsertAscending(b *testing.B) {
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = i
+	}
+	benchmarkInsert(b, values)
+}
+
+func BenchmarkInsertRandom(b *testing.B) {
+	benchmarkInsert(b, benchmarkValues(10000))
+}
+
+func BenchmarkDeleteRandom(b *testing.B) {
+	values := benchmarkValues(10000)
+	b.ReportAllocs()
+	for range b.N {
+		b.StopTimer()
+		tree := MakeTree[int]()
+		for _, v := range values {
+			tree.Insert(v)
+		}
+		b.StartTimer()
+		for i := len(values) - 1; i >= 0; i-- {
+			tree.Delete(values[i])
+		}
+	}
+}
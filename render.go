@@ -0,0 +1,222 @@
+package redblack
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// How [Tree.Render] arranges the nodes
+type Layout int
+
+const (
+	// The root is at the top and the children are below their parent
+	TopDown Layout = 0
+	// The root is on the left and the right subtree is above the left
+	// one. This is better suited for wide trees.
+	Sideways Layout = 1
+)
+
+// Options for [Tree.Render]
+type RenderOptions struct {
+	Layout Layout
+	// Draw red nodes with ANSI colors. Otherwise, red nodes are
+	// drawn in parentheses.
+	Color bool
+	// Nodes deeper than this are replaced with …, unless it is zero
+	MaxDepth int
+}
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// Draws the tree with box-drawing characters for a terminal.
+// Labels in the same column belong to the same node, so the
+// top-down layout can be read like a sorted list from left to right.
+func (t Tree[V]) Render(w io.Writer, opts RenderOptions) error {
+	return renderNode(w, t.node, opts)
+}
+
+func renderNode[V any, D any](w io.Writer, n *node[V, D], opts RenderOptions) error {
+	if n == nil {
+		_, err := io.WriteString(w, "EmptyTree\n")
+		return err
+	}
+
+	root := makeTextNode(n, opts, 1)
+	var lines []string
+	if opts.Layout == Sideways {
+		lines = root.sideways(nil, "", "")
+	} else {
+		lines = root.topDown()
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// A node as it is drawn
+type textNode struct {
+	label   string // the visible text
+	colored bool   // whether to wrap the label in ANSI colors
+	left    *textNode
+	right   *textNode
+	pos     int // first column of the label in the top-down layout
+}
+
+func makeTextNode[V any, D any](n *node[V, D], opts RenderOptions, depth int) *textNode {
+	if n == nil {
+		return nil
+	}
+	if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return &textNode{label: "…"}
+	}
+
+	t := &textNode{label: show(n.val)}
+	if n.color == Red {
+		if opts.Color {
+			t.colored = true
+		} else {
+			t.label = "(" + t.label + ")"
+		}
+	}
+	t.left = makeTextNode(n.left, opts, depth+1)
+	t.right = makeTextNode(n.right, opts, depth+1)
+	return t
+}
+
+func (t *textNode) width() int {
+	return utf8.RuneCountInString(t.label)
+}
+
+func (t *textNode) center() int {
+	return t.pos + (t.width()-1)/2
+}
+
+func (t *textNode) text() string {
+	if t.colored {
+		return ansiRed + t.label + ansiReset
+	}
+	return t.label
+}
+
+// Draws the right subtree above and the left subtree below the node.
+// The prefix goes in front of the connector that leads to the node.
+func (t *textNode) sideways(lines []string, prefix, connector string) []string {
+	if t.right != nil {
+		lines = t.right.sideways(lines, t.childPrefix(prefix, connector, "└── "), "┌── ")
+	}
+	lines = append(lines, prefix+connector+t.text())
+	if t.left != nil {
+		lines = t.left.sideways(lines, t.childPrefix(prefix, connector, "┌── "), "└── ")
+	}
+	return lines
+}
+
+// Continues the vertical line of the parent if the child is on the
+// other side of it.
+func (t *textNode) childPrefix(prefix, connector, crossing string) string {
+	switch connector {
+	case "":
+		return prefix
+	case crossing:
+		return prefix + "│   "
+	default:
+		return prefix + "    "
+	}
+}
+
+// Draws the tree level by level with a row of connectors below
+// every level that has children.
+func (t *textNode) topDown() []string {
+	// every node gets its own columns, ordered like the values
+	var levels [][]*textNode
+	pos := 0
+	var place func(n *textNode, depth int)
+	place = func(n *textNode, depth int) {
+		if n == nil {
+			return
+		}
+		place(n.left, depth+1)
+		n.pos = pos
+		pos += n.width() + 1
+		for len(levels) <= depth {
+			levels = append(levels, nil)
+		}
+		levels[depth] = append(levels[depth], n)
+		place(n.right, depth+1)
+	}
+	place(t, 0)
+
+	var lines []string
+	for _, level := range levels {
+		row := newTextRow(pos)
+		edges := newTextRow(pos)
+		hasEdges := false
+		for _, n := range level {
+			row.write(n)
+			if n.left == nil && n.right == nil {
+				continue
+			}
+			hasEdges = true
+			from, to := n.center(), n.center()
+			if n.left != nil {
+				from = n.left.center()
+			}
+			if n.right != nil {
+				to = n.right.center()
+			}
+			for i := from; i <= to; i++ {
+				edges[i] = "─"
+			}
+			switch {
+			case n.left != nil && n.right != nil:
+				edges[n.center()] = "┴"
+			case n.left != nil:
+				edges[n.center()] = "┘"
+			default:
+				edges[n.center()] = "└"
+			}
+			if n.left != nil {
+				edges[from] = "┌"
+			}
+			if n.right != nil {
+				edges[to] = "┐"
+			}
+		}
+		lines = append(lines, row.String())
+		if hasEdges {
+			lines = append(lines, edges.String())
+		}
+	}
+	return lines
+}
+
+// One line of the top-down layout with one cell per column
+type textRow []string
+
+func newTextRow(width int) textRow {
+	row := make(textRow, width)
+	for i := range row {
+		row[i] = " "
+	}
+	return row
+}
+
+func (r textRow) write(n *textNode) {
+	i := n.pos
+	for _, c := range n.label {
+		r[i] = string(c)
+		i++
+	}
+	if n.colored {
+		r[n.pos] = ansiRed + r[n.pos]
+		r[i-1] += ansiReset
+	}
+}
+
+func (r textRow) String() string {
+	return strings.TrimRight(strings.Join(r, ""), " ")
+}
@@ -0,0 +1,95 @@
+package redblack
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, tree Tree[int], opts RenderOptions) string {
+	var buf bytes.Buffer
+	if err := tree.Render(&buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRenderEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	if out := render(t, tree, RenderOptions{}); out != "EmptyTree\n" {
+		t.Fatalf("Expected 'EmptyTree' but got '%s'", out)
+	}
+}
+
+func TestRenderTopDown(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 1, 7, 3} {
+		tree.Insert(v)
+	}
+
+	expected := `      5
+┌─────┴─┐
+1       7
+└──┐
+  (3)
+`
+	if out := render(t, tree, RenderOptions{}); out != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, out)
+	}
+}
+
+func TestRenderSideways(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 1, 7, 3, 9, 10} {
+		tree.Insert(v)
+	}
+
+	expected := `    ┌── (10)
+┌── 9
+│   └── (7)
+5
+│   ┌── (3)
+└── 1
+`
+	if out := render(t, tree, RenderOptions{Layout: Sideways}); out != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, out)
+	}
+}
+
+func TestRenderMaxDepth(t *testing.T) {
+	tree := MakeTree[int]()
+	for v := range 20 {
+		tree.Insert(v)
+	}
+
+	out := render(t, tree, RenderOptions{Layout: Sideways, MaxDepth: 2})
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines but got %d:\n%s", len(lines), out)
+	}
+	if strings.Count(out, "…") != 4 {
+		t.Fatalf("Expected 4 truncated subtrees:\n%s", out)
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(1)
+
+	expected := "  5\n┌─┘\n" + ansiRed + "1" + ansiReset + "\n"
+	if out := render(t, tree, RenderOptions{Color: true}); out != expected {
+		t.Fatalf("Expected %q but got %q", expected, out)
+	}
+	if out := render(t, tree, RenderOptions{Layout: Sideways, Color: true}); strings.Contains(out, "(") {
+		t.Fatalf("Colored red nodes should not be in parentheses: %q", out)
+	}
+}
+
+func TestRenderWriterError(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(1)
+	if err := tree.Render(failingWriter{}, RenderOptions{}); err == nil {
+		t.Fatal("Expected error from writer")
+	}
+}
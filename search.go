@@ -0,0 +1,109 @@
+package redblack
+
+// The result of looking up a value in the tree
+type position[V any, D any] struct {
+	match  *node[V, D] // the node holding the value, if any
+	parent *node[V, D] // the parent of the value, if it were inserted
+	less   bool        // whether the value would be the left child of parent
+}
+
+// Looks up a value below n using the natural order.
+//
+// This is the only place where [Value] types are compared, so
+// they are compared with the operators directly instead of
+// going through a comparison function.
+func seek[V Value, D any](n *node[V, D], v V) position[V, D] {
+	var pos position[V, D]
+	for n != nil {
+		if v == n.val {
+			pos.match = n
+			return pos
+		}
+		pos.parent = n
+		pos.less = v < n.val
+		if pos.less {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return pos
+}
+
+// A pointer receiver, so that t.seek reads the root when it is
+// called and not when [Tree.Range] is
+func (t *Tree[V]) seek(v V) position[V, struct{}] {
+	return seek(t.node, v)
+}
+
+// Looks up a value below n using the comparison function
+func seekFunc[V any, D any](n *node[V, D], v V, compare func(a, b V) int) position[V, D] {
+	var pos position[V, D]
+	for n != nil {
+		c := compare(v, n.val)
+		if c == 0 {
+			pos.match = n
+			return pos
+		}
+		pos.parent = n
+		pos.less = c < 0
+		if pos.less {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return pos
+}
+
+// Returns the node with the biggest value that is smaller than
+// or equal to the looked up value
+func (pos position[V, D]) floor() *node[V, D] {
+	if pos.match != nil {
+		return pos.match
+	}
+	return pos.before()
+}
+
+// Returns the node with the smallest value that is bigger than
+// or equal to the looked up value
+func (pos position[V, D]) ceiling() *node[V, D] {
+	if pos.match != nil {
+		return pos.match
+	}
+	return pos.after()
+}
+
+// Returns the node with the biggest value that is strictly
+// smaller than the looked up value
+func (pos position[V, D]) lower() *node[V, D] {
+	if pos.match != nil {
+		return pos.match.prev()
+	}
+	return pos.before()
+}
+
+// Returns the node with the smallest value that is strictly
+// bigger than the looked up value
+func (pos position[V, D]) higher() *node[V, D] {
+	if pos.match != nil {
+		return pos.match.next()
+	}
+	return pos.after()
+}
+
+// Returns the node that would precede the value if it were inserted
+func (pos position[V, D]) before() *node[V, D] {
+	if pos.parent == nil || !pos.less {
+		return pos.parent
+	}
+	return pos.parent.prev()
+}
+
+// Returns the node that would follow the value if it were inserted
+func (pos position[V, D]) after() *node[V, D] {
+	if pos.parent == nil || pos.less {
+		return pos.parent
+	}
+	return pos.parent.next()
+}
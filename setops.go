@@ -0,0 +1,334 @@
+package redblack
+
+import "fmt"
+
+// Join creates a tree of all values in left, the pivot and all values in right.
+//
+// All values in left must be smaller than the pivot and all values
+// in right bigger, otherwise Join panics. The trees are joined in
+// O(log n) by attaching the smaller tree to the spine of the bigger
+// one at the node of the same black height. Both trees are consumed
+// and must not be used afterwards.
+func Join[V Value](left Tree[V], pivot V, right Tree[V]) Tree[V] {
+	if v, ok := left.Max(); ok && v >= pivot {
+		panic(fmt.Sprintf("Can't join, left tree contains %s which is not smaller than pivot %s", show(v), show(pivot)))
+	}
+	if v, ok := right.Min(); ok && v <= pivot {
+		panic(fmt.Sprintf("Can't join, right tree contains %s which is not bigger than pivot %s", show(v), show(pivot)))
+	}
+	return setResult(join(makeSubtree(left.node), &node[V, struct{}]{val: pivot}, makeSubtree(right.node)))
+}
+
+// Split divides the tree into the values smaller than k and the values bigger than k.
+//
+// The boolean reports whether k itself was in the tree. Splitting
+// takes O(log n), since the pieces along the search path are joined
+// in order of their black heights. The tree is consumed and must not
+// be used afterwards.
+func Split[V Value](t Tree[V], k V) (Tree[V], bool, Tree[V]) {
+	lt, match, gt := split(makeSubtree(t.node), k)
+	return setResult(lt), match != nil, setResult(gt)
+}
+
+// Union creates a tree of all values that are in a or b.
+//
+// Like the other set operations, this takes O(m log(n/m + 1)),
+// where m is the size of the smaller tree. Both trees are consumed:
+// they share their nodes with the result and must not be used
+// afterwards. Use [Tree.Clone] to keep them. The same tree may be
+// passed as both a and b.
+func Union[V Value](a, b Tree[V]) Tree[V] {
+	if a.node == b.node {
+		return a
+	}
+	return setResult(union(makeSubtree(a.node), makeSubtree(b.node)))
+}
+
+// Intersection creates a tree of all values that are in both a and b.
+//
+// Both trees are consumed, see [Union].
+func Intersection[V Value](a, b Tree[V]) Tree[V] {
+	if a.node == b.node {
+		return a
+	}
+	return setResult(intersection(makeSubtree(a.node), makeSubtree(b.node)))
+}
+
+// Difference creates a tree of all values in a that are not in b.
+//
+// Both trees are consumed, see [Union].
+func Difference[V Value](a, b Tree[V]) Tree[V] {
+	if a.node == b.node {
+		return MakeTree[V]()
+	}
+	return setResult(difference(makeSubtree(a.node), makeSubtree(b.node)))
+}
+
+// SymmetricDifference creates a tree of all values that are in either a or b, but not in both.
+//
+// Both trees are consumed, see [Union].
+func SymmetricDifference[V Value](a, b Tree[V]) Tree[V] {
+	if a.node == b.node {
+		return MakeTree[V]()
+	}
+	return setResult(symmetricDifference(makeSubtree(a.node), makeSubtree(b.node)))
+}
+
+// Checks whether all values in a are also in b
+//
+// Neither tree is modified.
+func IsSubset[V Value](a, b Tree[V]) bool {
+	if a.Size() > b.Size() {
+		return false
+	}
+	for v := range a.All() {
+		if !b.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Checks whether a and b contain the same values
+//
+// Neither tree is modified.
+func Equal[V Value](a, b Tree[V]) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	m := b.node.min()
+	for n := a.node.min(); n != nil; n = n.next() {
+		if n.val != m.val {
+			return false
+		}
+		m = m.next()
+	}
+	return true
+}
+
+func setResult[V Value](s subtree[V, struct{}]) Tree[V] {
+	return Tree[V]{rbtree[V, struct{}]{node: s.root}}
+}
+
+// A tree with a black root (or none at all) and its black height.
+//
+// The set operations pass the black height along instead of
+// counting it again, so that joining two trees only has to walk
+// down as many levels as their black heights differ.
+type subtree[V any, D any] struct {
+	root   *node[V, D]
+	height int
+}
+
+// Detaches n from its parent and counts the black height once
+func makeSubtree[V any, D any](n *node[V, D]) subtree[V, D] {
+	n = detach(n)
+	return subtree[V, D]{root: n, height: n.blackHeight()}
+}
+
+// Makes n the root of its own tree, which means it has no parent
+// and is black.
+func detach[V any, D any](n *node[V, D]) *node[V, D] {
+	if n != nil {
+		n.p = nil
+		n.color = Black
+	}
+	return n
+}
+
+// Returns the number of black nodes from n to a leaf
+func (n *node[V, D]) blackHeight() int {
+	h := 0
+	for ; n != nil; n = n.left {
+		if n.color == Black {
+			h++
+		}
+	}
+	return h
+}
+
+// Detaches the children of the root into trees of their own
+func (s subtree[V, D]) children() (subtree[V, D], subtree[V, D]) {
+	return s.child(s.root.left), s.child(s.root.right)
+}
+
+func (s subtree[V, D]) child(c *node[V, D]) subtree[V, D] {
+	// below the black root, there is one black node less, unless
+	// the child is red and becomes black as a root
+	h := s.height - 1
+	if c != nil && c.color == Red {
+		h++
+	}
+	return subtree[V, D]{root: detach(c), height: h}
+}
+
+// Joins the trees with the pivot node in between.
+//
+// This takes O(|lh - rh| + 1), where lh and rh are the black heights
+// of the trees.
+func join[V any, D any](left subtree[V, D], pivot *node[V, D], right subtree[V, D]) subtree[V, D] {
+	lh, rh := left.height, right.height
+
+	pivot.p = nil
+	pivot.color = Red
+	if lh == rh {
+		pivot.color = Black
+		pivot.attach(left.root, right.root)
+		return subtree[V, D]{root: pivot, height: lh + 1}
+	}
+
+	var root, parent *node[V, D]
+	if lh > rh {
+		// walk down the right spine of left to the
+		// first black node with the black height of right
+		root = left.root
+		c, h := left.root, lh
+		for c != nil && (c.color != Black || h != rh) {
+			if c.color == Black {
+				h--
+			}
+			parent, c = c, c.right
+		}
+		parent.right = pivot
+		pivot.attach(c, right.root)
+	} else {
+		root = right.root
+		c, h := right.root, rh
+		for c != nil && (c.color != Black || h != lh) {
+			if c.color == Black {
+				h--
+			}
+			parent, c = c, c.left
+		}
+		parent.left = pivot
+		pivot.attach(left.root, c)
+	}
+
+	pivot.p = parent
+	for p := parent; p != nil; p = p.p {
+		p.updateSize()
+	}
+
+	t := rbtree[V, D]{node: root}
+	t.fixViolations(pivot, nil)
+	// a red root turns black, which adds a black node to every path
+	height := max(lh, rh)
+	if t.node.color == Red {
+		t.node.color = Black
+		height++
+	}
+	return subtree[V, D]{root: t.node, height: height}
+}
+
+// Joins the trees without a pivot
+func join2[V any, D any](left subtree[V, D], right subtree[V, D]) subtree[V, D] {
+	if left.root == nil {
+		return right
+	}
+	if right.root == nil {
+		return left
+	}
+
+	// the biggest node of left becomes the pivot
+	rest, pivot := left.splitLast()
+	return join(rest, pivot, right)
+}
+
+// Removes the biggest node and returns the remaining tree and the node.
+//
+// The joins along the right spine take O(log n) in total, because
+// the black heights of the joined trees grow with every step.
+func (s subtree[V, D]) splitLast() (subtree[V, D], *node[V, D]) {
+	left, right := s.children()
+	if right.root == nil {
+		return left, s.root
+	}
+	rest, last := right.splitLast()
+	return join(left, s.root, rest), last
+}
+
+// Sets the children of n and recomputes its size
+func (n *node[V, D]) attach(left *node[V, D], right *node[V, D]) {
+	n.left, n.right = left, right
+	if left != nil {
+		left.p = n
+	}
+	if right != nil {
+		right.p = n
+	}
+	n.updateSize()
+}
+
+// Splits the tree into the nodes smaller than k, the node with k
+// (if any) and the nodes bigger than k.
+func split[V Value, D any](s subtree[V, D], k V) (subtree[V, D], *node[V, D], subtree[V, D]) {
+	if s.root == nil {
+		return s, nil, s
+	}
+
+	n := s.root
+	left, right := s.children()
+	if k == n.val {
+		return left, n, right
+	} else if k < n.val {
+		lt, match, gt := split(left, k)
+		return lt, match, join(gt, n, right)
+	} else {
+		lt, match, gt := split(right, k)
+		return join(left, n, lt), match, gt
+	}
+}
+
+func union[V Value, D any](a subtree[V, D], b subtree[V, D]) subtree[V, D] {
+	if a.root == nil {
+		return b
+	}
+	if b.root == nil {
+		return a
+	}
+
+	left, right := a.children()
+	bl, _, br := split(b, a.root.val)
+	return join(union(left, bl), a.root, union(right, br))
+}
+
+func intersection[V Value, D any](a subtree[V, D], b subtree[V, D]) subtree[V, D] {
+	if a.root == nil || b.root == nil {
+		return subtree[V, D]{}
+	}
+
+	left, right := a.children()
+	bl, match, br := split(b, a.root.val)
+	l, r := intersection(left, bl), intersection(right, br)
+	if match != nil {
+		return join(l, a.root, r)
+	}
+	return join2(l, r)
+}
+
+func difference[V Value, D any](a subtree[V, D], b subtree[V, D]) subtree[V, D] {
+	if a.root == nil || b.root == nil {
+		return a
+	}
+
+	left, right := b.children()
+	al, _, ar := split(a, b.root.val)
+	return join2(difference(al, left), difference(ar, right))
+}
+
+func symmetricDifference[V Value, D any](a subtree[V, D], b subtree[V, D]) subtree[V, D] {
+	if a.root == nil {
+		return b
+	}
+	if b.root == nil {
+		return a
+	}
+
+	left, right := a.children()
+	bl, match, br := split(b, a.root.val)
+	l, r := symmetricDifference(left, bl), symmetricDifference(right, br)
+	if match != nil {
+		return join2(l, r)
+	}
+	return join(l, a.root, r)
+}
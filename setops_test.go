@@ -0,0 +1,230 @@
+package redblack
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func makeSetTree(values ...int) Tree[int] {
+	tree := MakeTree[int]()
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	return tree
+}
+
+func randomSet(r *rand.Rand, size, limit int) map[int]bool {
+	set := map[int]bool{}
+	for range size {
+		set[r.IntN(limit)] = true
+	}
+	return set
+}
+
+func treeOf(set map[int]bool) Tree[int] {
+	tree := MakeTree[int]()
+	for v := range set {
+		tree.Insert(v)
+	}
+	return tree
+}
+
+func validateSetResult(t *testing.T, tree Tree[int], expected func(v int) bool, limit int) {
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+
+	var values []int
+	for v := range limit {
+		if expected(v) {
+			values = append(values, v)
+		}
+	}
+	if actual := slices.Collect(tree.All()); !slices.Equal(actual, values) {
+		t.Fatalf("Expected %v but got %v", values, actual)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	for _, sizes := range [][2]int{{0, 0}, {0, 10}, {10, 0}, {1, 100}, {100, 1}, {50, 50}, {3, 1000}} {
+		left := MakeTree[int]()
+		for i := range sizes[0] {
+			left.Insert(i)
+		}
+		right := MakeTree[int]()
+		for i := range sizes[1] {
+			right.Insert(sizes[0] + 1 + i)
+		}
+
+		tree := Join(left, sizes[0], right)
+		validateSetResult(t, tree, func(v int) bool { return v <= sizes[0]+sizes[1] }, 2000)
+	}
+}
+
+func TestJoinPanicsOnOverlap(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("Did not panic")
+		}
+	}()
+
+	Join(makeSetTree(1, 5), 3, makeSetTree(7))
+}
+
+func TestSplit(t *testing.T) {
+	for _, k := range []int{-1, 0, 17, 50, 51, 99, 100} {
+		tree := MakeTree[int]()
+		for i := range 100 {
+			tree.Insert(i)
+		}
+
+		lt, found, gt := Split(tree, k)
+		if found != (k >= 0 && k < 100) {
+			t.Fatalf("Expected found to be %v for %d", !found, k)
+		}
+		validateSetResult(t, lt, func(v int) bool { return v < k && v < 100 }, 100)
+		validateSetResult(t, gt, func(v int) bool { return v > k && v >= 0 }, 100)
+	}
+}
+
+func TestSetOperationsSmall(t *testing.T) {
+	union := Union(makeSetTree(1, 2, 3), makeSetTree(3, 4))
+	if !Equal(union, makeSetTree(1, 2, 3, 4)) {
+		t.Fatalf("Unexpected union %v", slices.Collect(union.All()))
+	}
+	intersection := Intersection(makeSetTree(1, 2, 3), makeSetTree(3, 4))
+	if !Equal(intersection, makeSetTree(3)) {
+		t.Fatalf("Unexpected intersection %v", slices.Collect(intersection.All()))
+	}
+	difference := Difference(makeSetTree(1, 2, 3), makeSetTree(3, 4))
+	if !Equal(difference, makeSetTree(1, 2)) {
+		t.Fatalf("Unexpected difference %v", slices.Collect(difference.All()))
+	}
+	symmetric := SymmetricDifference(makeSetTree(1, 2, 3), makeSetTree(3, 4))
+	if !Equal(symmetric, makeSetTree(1, 2, 4)) {
+		t.Fatalf("Unexpected symmetric difference %v", slices.Collect(symmetric.All()))
+	}
+}
+
+func TestSetOperationsEmpty(t *testing.T) {
+	empty := MakeTree[int]
+	if Union(empty(), empty()).Size() != 0 {
+		t.Fatalf("Union of empty trees is not empty")
+	}
+	if !Equal(Union(empty(), makeSetTree(1, 2)), makeSetTree(1, 2)) {
+		t.Fatalf("Union with empty tree changed values")
+	}
+	if Intersection(makeSetTree(1, 2), empty()).Size() != 0 {
+		t.Fatalf("Intersection with empty tree is not empty")
+	}
+	if !Equal(Difference(makeSetTree(1, 2), empty()), makeSetTree(1, 2)) {
+		t.Fatalf("Difference with empty tree changed values")
+	}
+	if Difference(empty(), makeSetTree(1, 2)).Size() != 0 {
+		t.Fatalf("Difference of empty tree is not empty")
+	}
+	if !Equal(SymmetricDifference(empty(), makeSetTree(1, 2)), makeSetTree(1, 2)) {
+		t.Fatalf("Symmetric difference with empty tree changed values")
+	}
+}
+
+func TestSetOperationsSameTree(t *testing.T) {
+	same := func(op func(a, b Tree[int]) Tree[int]) Tree[int] {
+		tree := MakeTree[int]()
+		for i := range 100 {
+			tree.Insert(i)
+		}
+		result := op(tree, tree)
+		if err := result.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	all := func(v int) bool { return true }
+	none := func(v int) bool { return false }
+	validateSetResult(t, same(Union[int]), all, 100)
+	validateSetResult(t, same(Intersection[int]), all, 100)
+	validateSetResult(t, same(Difference[int]), none, 100)
+	validateSetResult(t, same(SymmetricDifference[int]), none, 100)
+}
+
+func TestSetOperationsRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	limit := 2000
+	for _, sizes := range [][2]int{{10, 10}, {500, 500}, {5, 1000}, {1000, 5}, {1000, 1000}} {
+		a, b := randomSet(r, sizes[0], limit), randomSet(r, sizes[1], limit)
+
+		validateSetResult(t, Union(treeOf(a), treeOf(b)), func(v int) bool { return a[v] || b[v] }, limit)
+		validateSetResult(t, Intersection(treeOf(a), treeOf(b)), func(v int) bool { return a[v] && b[v] }, limit)
+		validateSetResult(t, Difference(treeOf(a), treeOf(b)), func(v int) bool { return a[v] && !b[v] }, limit)
+		validateSetResult(t, SymmetricDifference(treeOf(a), treeOf(b)), func(v int) bool { return a[v] != b[v] }, limit)
+	}
+}
+
+func TestSetOperationsTrackBlackHeight(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	limit := 2000
+	check := func(op string, s subtree[int, struct{}]) {
+		if s.height != s.root.blackHeight() {
+			t.Fatalf("%s passed on black height %d, but the tree has %d", op, s.height, s.root.blackHeight())
+		}
+	}
+	for _, sizes := range [][2]int{{10, 10}, {500, 500}, {5, 1000}, {1000, 5}} {
+		a, b := randomSet(r, sizes[0], limit), randomSet(r, sizes[1], limit)
+		subtreeOf := func(set map[int]bool) subtree[int, struct{}] {
+			return makeSubtree(treeOf(set).node)
+		}
+
+		check("union", union(subtreeOf(a), subtreeOf(b)))
+		check("intersection", intersection(subtreeOf(a), subtreeOf(b)))
+		check("difference", difference(subtreeOf(a), subtreeOf(b)))
+		check("symmetricDifference", symmetricDifference(subtreeOf(a), subtreeOf(b)))
+		lt, _, gt := split(subtreeOf(a), limit/2)
+		check("split", lt)
+		check("split", gt)
+		check("join2", join2(lt, gt))
+	}
+}
+
+func TestSetResultCanBeModified(t *testing.T) {
+	tree := Union(makeSetTree(1, 3, 5, 7), makeSetTree(2, 4, 6, 8))
+	for i := range 100 {
+		tree.Insert(i)
+	}
+	for i := 0; i < 100; i += 2 {
+		tree.Delete(i)
+	}
+	validateSetResult(t, tree, func(v int) bool { return v%2 == 1 }, 100)
+}
+
+func TestIsSubset(t *testing.T) {
+	if !IsSubset(makeSetTree(), makeSetTree(1)) {
+		t.Fatalf("Empty tree is not a subset")
+	}
+	if !IsSubset(makeSetTree(1, 3), makeSetTree(1, 2, 3)) {
+		t.Fatalf("{1, 3} is not a subset of {1, 2, 3}")
+	}
+	if IsSubset(makeSetTree(1, 4), makeSetTree(1, 2, 3)) {
+		t.Fatalf("{1, 4} is a subset of {1, 2, 3}")
+	}
+	if IsSubset(makeSetTree(1, 2, 3), makeSetTree(1, 2)) {
+		t.Fatalf("Bigger tree is a subset")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal(makeSetTree(), makeSetTree()) {
+		t.Fatalf("Empty trees are not equal")
+	}
+	if !Equal(makeSetTree(3, 2, 1), makeSetTree(1, 2, 3)) {
+		t.Fatalf("Trees with the same values are not equal")
+	}
+	if Equal(makeSetTree(1, 2), makeSetTree(1, 3)) {
+		t.Fatalf("Trees with different values are equal")
+	}
+	if Equal(makeSetTree(1, 2), makeSetTree(1, 2, 3)) {
+		t.Fatalf("Trees of different sizes are equal")
+	}
+}
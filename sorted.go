@@ -0,0 +1,66 @@
+package redblack
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// Returned when building a tree from values that are not sorted
+var ErrUnsorted = errors.New("values are not sorted")
+
+// FromSorted creates a tree from values in ascending order.
+//
+// The tree is built in O(n) and is perfectly balanced. All nodes
+// are black, except for the deepest level if it is incomplete,
+// which is red. Duplicates are skipped, whereas values that are
+// not in ascending order are rejected with [ErrUnsorted].
+func FromSorted[V Value](values []V) (Tree[V], error) {
+	unique := make([]V, 0, len(values))
+	for i, v := range values {
+		if i > 0 && v < values[i-1] {
+			return Tree[V]{}, fmt.Errorf("%w: %s at index %d is smaller than %s", ErrUnsorted, show(v), i, show(values[i-1]))
+		}
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
+	}
+
+	// a perfect tree of height h has 2^h - 1 nodes, every
+	// other tree has an incomplete deepest level
+	redDepth := -1
+	if len(unique)&(len(unique)+1) != 0 {
+		redDepth = bits.Len(uint(len(unique))) - 1
+	}
+
+	return Tree[V]{rbtree[V, struct{}]{node: build(unique, nil, 0, redDepth)}}, nil
+}
+
+// FromSeq creates a tree from a sequence of values in ascending order.
+//
+// See [FromSorted] for the details.
+func FromSeq[V Value](seq iter.Seq[V]) (Tree[V], error) {
+	var values []V
+	for v := range seq {
+		values = append(values, v)
+	}
+	return FromSorted(values)
+}
+
+// Builds a balanced subtree from the values, where the middle
+// value becomes the root of the subtree
+func build[V Value](values []V, parent *node[V, struct{}], depth int, redDepth int) *node[V, struct{}] {
+	if len(values) == 0 {
+		return nil
+	}
+
+	mid := len(values) / 2
+	n := &node[V, struct{}]{val: values[mid], color: Black, size: len(values), p: parent}
+	if depth == redDepth {
+		n.color = Red
+	}
+	n.left = build(values[:mid], n, depth+1, redDepth)
+	n.right = build(values[mid+1:], n, depth+1, redDepth)
+	return n
+}
@@ -0,0 +1,111 @@
+package redblack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFromSortedEmpty(t *testing.T) {
+	tree, err := FromSorted([]int{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Tree is not empty")
+	}
+	tree.Insert(1)
+	if !tree.Contains(1) {
+		t.Fatalf("Tree does not contain 1")
+	}
+}
+
+func TestFromSortedAllSizes(t *testing.T) {
+	for size := range 100 {
+		values := make([]int, size)
+		for i := range values {
+			values[i] = i * 3
+		}
+
+		tree, err := FromSorted(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(slices.Collect(tree.All()), values) {
+			t.Fatalf("Tree of size %d does not contain the values", size)
+		}
+		validateTreeProperties(t, tree.node)
+		validateParentRefs(t, tree.node)
+		validateSizes(t, tree.node)
+
+		tree.Insert(-1)
+		tree.Delete(0)
+		validateTreeProperties(t, tree.node)
+	}
+}
+
+func TestFromSortedPerfectTreeIsBlack(t *testing.T) {
+	tree, err := FromSorted([]int{1, 2, 3, 4, 5, 6, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := tree.node.min(); n != nil; n = n.next() {
+		if n.color != Black {
+			t.Fatalf("%d is not black", n.val)
+		}
+	}
+	if tree.Height() != 3 {
+		t.Fatalf("Expected height 3 but got %d", tree.Height())
+	}
+}
+
+func TestFromSortedDuplicates(t *testing.T) {
+	tree, err := FromSorted([]string{"a", "a", "b", "c", "c", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values := slices.Collect(tree.All()); !slices.Equal(values, []string{"a", "b", "c"}) {
+		t.Fatalf("Duplicates were not skipped: %v", values)
+	}
+	validateTreeProperties(t, tree.node)
+}
+
+func TestFromSortedUnsorted(t *testing.T) {
+	_, err := FromSorted([]int{1, 2, 5, 4})
+	if !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("Expected ErrUnsorted but got %v", err)
+	}
+	expected := "values are not sorted: 4 at index 3 is smaller than 5"
+	if err.Error() != expected {
+		t.Fatalf("Expected '%s' but got '%s'", expected, err)
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	tree, err := FromSeq(slices.Values([]int{1, 2, 3, 4, 5}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Size() != 5 {
+		t.Fatalf("Expected size 5 but got %d", tree.Size())
+	}
+	validateTreeProperties(t, tree.node)
+
+	source := MakeTree[int]()
+	for i := range 50 {
+		source.Insert(i)
+	}
+	copied, err := FromSeq(source.All())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Collect(copied.All()), slices.Collect(source.All())) {
+		t.Fatalf("Tree built from another tree differs")
+	}
+}
+
+func TestFromSeqUnsorted(t *testing.T) {
+	if _, err := FromSeq(slices.Values([]int{3, 2, 1})); !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("Expected ErrUnsorted but got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package redblack
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Options for [Tree.WriteSVG]
+type SVGOptions[V any] struct {
+	// Draw the nil leaves as small black boxes
+	NilLeaves bool
+	// Formats the label of a node. By default, the value is
+	// formatted with fmt.
+	Label func(V) string
+}
+
+const (
+	svgRadius    = 16.0 // of a node with a short label
+	svgCharWidth = 7.5  // roughly, at the font size of 12
+	svgLeaf      = 10.0 // width and height of a nil leaf
+	svgGap       = 12.0 // between neighbouring subtrees
+	svgLevel     = 56.0 // between the centers of two levels
+	svgMargin    = 8.0
+)
+
+// Draws the tree as an SVG image.
+//
+// The nodes are placed with a tidy tree layout in the style of
+// Reingold and Tilford: every subtree is laid out on its own and
+// then pushed as close to its sibling as possible without touching
+// it on any level. Parents are centered above their children, so
+// the picture is symmetric where the tree is.
+func (t Tree[V]) WriteSVG(w io.Writer, opts SVGOptions[V]) error {
+	label := opts.Label
+	if label == nil {
+		label = show[V]
+	}
+
+	root := makeSVGBox(t.node, opts.NilLeaves, label)
+	var acc strings.Builder
+	if root == nil {
+		size := 2 * svgMargin
+		fmt.Fprintf(&acc, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\"></svg>\n", size, size, size, size)
+		_, err := io.WriteString(w, acc.String())
+		return err
+	}
+
+	contour := root.layout()
+	left, right := contour.left[0], contour.right[0]
+	for d := range contour.left {
+		left = min(left, contour.left[d])
+		right = max(right, contour.right[d])
+	}
+	root.place(svgMargin-left, svgMargin+svgRadius)
+
+	width := right - left + 2*svgMargin
+	height := float64(len(contour.left)-1)*svgLevel + 2*(svgMargin+svgRadius)
+	fmt.Fprintf(&acc, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\" font-family=\"sans-serif\" font-size=\"12\">\n", width, height, width, height)
+	acc.WriteString("<g stroke=\"#555\" stroke-width=\"1.5\">\n")
+	root.edges(&acc)
+	acc.WriteString("</g>\n<g text-anchor=\"middle\" dominant-baseline=\"central\" fill=\"white\">\n")
+	root.nodes(&acc)
+	acc.WriteString("</g>\n</svg>\n")
+
+	_, err := io.WriteString(w, acc.String())
+	return err
+}
+
+// A node or nil leaf in the layout
+type svgBox struct {
+	label string
+	red   bool
+	leaf  bool // a nil leaf
+	left  *svgBox
+	right *svgBox
+	// x relative to the parent while laying out, absolute afterwards
+	x float64
+	y float64
+}
+
+// The leftmost and rightmost x of a subtree on every level,
+// relative to the root of the subtree
+type svgContour struct {
+	left  []float64
+	right []float64
+}
+
+func makeSVGBox[V any, D any](n *node[V, D], nilLeaves bool, label func(V) string) *svgBox {
+	if n == nil {
+		if nilLeaves {
+			return &svgBox{leaf: true}
+		}
+		return nil
+	}
+	return &svgBox{
+		label: label(n.val),
+		red:   n.color == Red,
+		left:  makeSVGBox(n.left, nilLeaves, label),
+		right: makeSVGBox(n.right, nilLeaves, label),
+	}
+}
+
+// Half of the horizontal space the box needs
+func (b *svgBox) halfWidth() float64 {
+	if b.leaf {
+		return svgLeaf / 2
+	}
+	return max(svgRadius, float64(utf8.RuneCountInString(b.label))*svgCharWidth/2+6)
+}
+
+// Lays out the subtree and sets the x of the children relative to b
+func (b *svgBox) layout() svgContour {
+	// a missing child takes up a single point, so that an only
+	// child still moves to its side
+	point := svgContour{left: []float64{0}, right: []float64{0}}
+	left, right := point, point
+	if b.left != nil {
+		left = b.left.layout()
+	}
+	if b.right != nil {
+		right = b.right.layout()
+	}
+
+	// the distance that keeps the children apart on all levels
+	sep := 0.0
+	for d := 0; d < len(left.right) && d < len(right.left); d++ {
+		sep = max(sep, left.right[d]-right.left[d]+svgGap)
+	}
+	if b.left != nil {
+		b.left.x = -sep / 2
+	}
+	if b.right != nil {
+		b.right.x = sep / 2
+	}
+
+	half := b.halfWidth()
+	contour := svgContour{left: []float64{-half}, right: []float64{half}}
+	if b.left == nil {
+		left = svgContour{}
+	}
+	if b.right == nil {
+		right = svgContour{}
+	}
+	for d := 0; d < max(len(left.left), len(right.left)); d++ {
+		var lo, hi float64
+		switch {
+		case d >= len(left.left):
+			lo, hi = right.left[d]+sep/2, right.right[d]+sep/2
+		case d >= len(right.left):
+			lo, hi = left.left[d]-sep/2, left.right[d]-sep/2
+		default:
+			lo, hi = left.left[d]-sep/2, right.right[d]+sep/2
+		}
+		contour.left = append(contour.left, lo)
+		contour.right = append(contour.right, hi)
+	}
+	return contour
+}
+
+// Turns the relative x into absolute coordinates
+func (b *svgBox) place(x, y float64) {
+	b.x += x
+	b.y = y
+	for _, c := range []*svgBox{b.left, b.right} {
+		if c != nil {
+			c.place(b.x, y+svgLevel)
+		}
+	}
+}
+
+func (b *svgBox) edges(acc *strings.Builder) {
+	for _, c := range []*svgBox{b.left, b.right} {
+		if c != nil {
+			fmt.Fprintf(acc, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\"/>\n", b.x, b.y, c.x, c.y)
+			c.edges(acc)
+		}
+	}
+}
+
+func (b *svgBox) nodes(acc *strings.Builder) {
+	if b.leaf {
+		fmt.Fprintf(acc, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%g\" height=\"%g\" fill=\"black\"/>\n", b.x-svgLeaf/2, b.y-svgLeaf/2, svgLeaf, svgLeaf)
+		return
+	}
+
+	fill := "black"
+	if b.red {
+		fill = "#d0021b"
+	}
+	fmt.Fprintf(acc, "<ellipse cx=\"%.1f\" cy=\"%.1f\" rx=\"%.1f\" ry=\"%g\" fill=\"%s\"/>\n", b.x, b.y, b.halfWidth(), svgRadius, fill)
+	fmt.Fprintf(acc, "<text x=\"%.1f\" y=\"%.1f\">%s</text>\n", b.x, b.y, html.EscapeString(b.label))
+	for _, c := range []*svgBox{b.left, b.right} {
+		if c != nil {
+			c.nodes(acc)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package redblack
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWriteSVGEmpty(t *testing.T) {
+	tree := MakeTree[int]()
+	var buf bytes.Buffer
+	if err := tree.WriteSVG(&buf, SVGOptions[int]{}); err != nil {
+		t.Fatal(err)
+	}
+	validateXML(t, buf.String())
+	if strings.Contains(buf.String(), "<ellipse") {
+		t.Fatalf("Empty tree has nodes: %s", buf.String())
+	}
+}
+
+func TestWriteSVGSmallTree(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(1)
+	tree.Insert(7)
+
+	var buf bytes.Buffer
+	if err := tree.WriteSVG(&buf, SVGOptions[int]{}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	validateXML(t, out)
+	if strings.Count(out, "<ellipse") != 3 || strings.Count(out, "<line") != 2 {
+		t.Fatalf("Expected 3 nodes and 2 edges: %s", out)
+	}
+	if strings.Count(out, `fill="#d0021b"`) != 2 || strings.Count(out, `fill="black"`) != 1 {
+		t.Fatalf("Expected 2 red and 1 black node: %s", out)
+	}
+	// the root is centered between its children
+	if !strings.Contains(out, `<ellipse cx="46.0" cy="24.0"`) {
+		t.Fatalf("Root is not centered: %s", out)
+	}
+}
+
+func TestWriteSVGNilLeavesAndLabels(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(1)
+
+	var buf bytes.Buffer
+	opts := SVGOptions[int]{
+		NilLeaves: true,
+		Label:     func(v int) string { return "<" + show(v) + ">" },
+	}
+	if err := tree.WriteSVG(&buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	validateXML(t, out)
+	if strings.Count(out, "<rect") != 3 {
+		t.Fatalf("Expected 3 nil leaves: %s", out)
+	}
+	if !strings.Contains(out, "&lt;5&gt;") {
+		t.Fatalf("Label was not used or not escaped: %s", out)
+	}
+}
+
+func TestWriteSVGWriterError(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(1)
+	if err := tree.WriteSVG(failingWriter{}, SVGOptions[int]{}); err == nil {
+		t.Fatal("Expected error from writer")
+	}
+}
+
+func TestSVGLayoutDoesNotOverlap(t *testing.T) {
+	tree := MakeTree[int]()
+	for i := range 5000 {
+		tree.Insert((i * 7919) % 5000)
+	}
+
+	for _, nilLeaves := range []bool{false, true} {
+		root := makeSVGBox(tree.node, nilLeaves, func(v int) string { return show(v * 1000) })
+		root.layout()
+		root.place(0, 0)
+
+		levels := map[float64][]*svgBox{}
+		var collect func(b *svgBox)
+		collect = func(b *svgBox) {
+			if b == nil {
+				return
+			}
+			collect(b.left)
+			levels[b.y] = append(levels[b.y], b)
+			collect(b.right)
+			if b.left != nil && b.right != nil && b.x-b.left.x != b.right.x-b.x {
+				t.Fatalf("%s is not centered above its children", b.label)
+			}
+		}
+		collect(root)
+
+		for y, boxes := range levels {
+			sort.SliceStable(boxes, func(i, j int) bool { return boxes[i].x < boxes[j].x })
+			for i := 1; i < len(boxes); i++ {
+				a, b := boxes[i-1], boxes[i]
+				if a.x+a.halfWidth() > b.x-b.halfWidth() {
+					t.Fatalf("%s and %s overlap on level %g", a.label, b.label, y)
+				}
+			}
+		}
+	}
+}
+
+func validateXML(t *testing.T, s string) {
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("Invalid XML: %v\n%s", err, s)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package redblack
+
+import (
+	"iter"
+	"sync"
+)
+
+// A Red-Black Tree that is safe for concurrent use
+//
+// Readers share a read lock and can proceed in parallel, whereas
+// writers hold an exclusive lock. The zero value is an empty tree
+// ready to use. A SyncTree must not be copied after first use.
+type SyncTree[V Value] struct {
+	mu   sync.RWMutex
+	tree Tree[V]
+}
+
+// MakeSyncTree creates a new Red-Black Tree for concurrent use
+func MakeSyncTree[V Value]() *SyncTree[V] {
+	return &SyncTree[V]{}
+}
+
+// Insert a value into a the tree.
+//
+// If the value already exists, nothing happens
+func (t *SyncTree[V]) Insert(v V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.Insert(v)
+}
+
+// Delete a value from the tree.
+//
+// Returns whether the value was in the tree
+func (t *SyncTree[V]) Delete(v V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Delete(v)
+}
+
+// Removes and returns the smallest value in the tree
+func (t *SyncTree[V]) PopMin() (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.PopMin()
+}
+
+// Removes and returns the biggest value in the tree
+func (t *SyncTree[V]) PopMax() (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.PopMax()
+}
+
+// Checks whether the specified value is in the tree
+func (t *SyncTree[V]) Contains(v V) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Contains(v)
+}
+
+// Returns the total number of nodes in the tree
+func (t *SyncTree[V]) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Size()
+}
+
+// Returns the smallest value in the tree
+func (t *SyncTree[V]) Min() (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Min()
+}
+
+// Returns the biggest value in the tree
+func (t *SyncTree[V]) Max() (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Max()
+}
+
+// Returns the largest value that is smaller than or equal to v
+func (t *SyncTree[V]) Floor(v V) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Floor(v)
+}
+
+// Returns the smallest value that is bigger than or equal to v
+func (t *SyncTree[V]) Ceiling(v V) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Ceiling(v)
+}
+
+// Returns the number of values between lo and hi
+func (t *SyncTree[V]) RangeCount(lo, hi Bound[V]) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.RangeCount(lo, hi)
+}
+
+// Returns an iterator over all values in ascending order.
+//
+// The read lock is held for the duration of the loop, which
+// means writers are blocked until the loop ends. The loop body
+// must not call any method of the same SyncTree, not even one that
+// only reads: read locks cannot be taken recursively, so it
+// deadlocks as soon as a writer is waiting. Iterate over a
+// [SyncTree.Snapshot] instead for long-running loops or to use the
+// tree in the loop body.
+func (t *SyncTree[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+		for v := range t.tree.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over all values between lo and hi in ascending order.
+//
+// The read lock is held for the duration of the loop, so the loop
+// body must not call any method of the same SyncTree, see
+// [SyncTree.All].
+func (t *SyncTree[V]) Range(lo, hi Bound[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+		for v := range t.tree.Range(lo, hi) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a copy of the tree at this point in time.
+//
+// The copy is not synchronized and is not affected by any later
+// modifications, so it can be iterated without holding a lock.
+func (t *SyncTree[V]) Snapshot() Tree[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Clone()
+}
@@ -0,0 +1,142 @@
+package redblack
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSyncTreeZeroValue(t *testing.T) {
+	var tree SyncTree[int]
+	tree.Insert(5)
+	if !tree.Contains(5) {
+		t.Fatalf("Tree does not contain 5")
+	}
+	if tree.Size() != 1 {
+		t.Fatalf("Tree has not size 1")
+	}
+}
+
+func TestSyncTreeConcurrentWriters(t *testing.T) {
+	tree := MakeSyncTree[int]()
+	var wg sync.WaitGroup
+	for g := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				tree.Insert(g*500 + i)
+			}
+			for i := 0; i < 500; i += 2 {
+				tree.Delete(g*500 + i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tree.Size() != 16*250 {
+		t.Fatalf("Expected size %d but got %d", 16*250, tree.Size())
+	}
+	snapshot := tree.Snapshot()
+	validateTreeProperties(t, snapshot.node)
+	validateParentRefs(t, snapshot.node)
+	validateSizes(t, snapshot.node)
+}
+
+func TestSyncTreeConcurrentReadersAndWriters(t *testing.T) {
+	tree := MakeSyncTree[int]()
+	for i := range 1000 {
+		tree.Insert(i * 2)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				tree.Insert(i*2 + 1)
+				tree.Delete(i*2 + 1)
+				// only the biggest values go missing for a moment
+				if v, ok := tree.PopMax(); ok {
+					tree.Insert(v)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range 900 {
+				if !tree.Contains(i * 2) {
+					t.Errorf("Tree lost %d", i*2)
+					return
+				}
+				if v, ok := tree.Floor(i*2 + 1); !ok || v < i*2 {
+					t.Errorf("Unexpected floor %d for %d", v, i*2+1)
+					return
+				}
+				tree.RangeCount(Inclusive(i), Unbounded[int]())
+				tree.Min()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSyncTreeIterationIsConsistent(t *testing.T) {
+	tree := MakeSyncTree[int]()
+	for i := range 100 {
+		tree.Insert(i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			tree.Insert(100 + i)
+			tree.Delete(100 + i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			prev := -1
+			for v := range tree.All() {
+				if v <= prev {
+					t.Errorf("Iteration is not ordered: %d after %d", v, prev)
+					return
+				}
+				prev = v
+			}
+			count := 0
+			for range tree.Range(Inclusive(10), Exclusive(20)) {
+				count++
+			}
+			if count != 10 {
+				t.Errorf("Expected 10 values in range but got %d", count)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+func TestSyncTreeSnapshot(t *testing.T) {
+	tree := MakeSyncTree[int]()
+	for i := range 10 {
+		tree.Insert(i)
+	}
+
+	snapshot := tree.Snapshot()
+	for v := range snapshot.All() {
+		// modifying while iterating the snapshot does not deadlock
+		tree.Delete(v)
+	}
+
+	if tree.Size() != 0 {
+		t.Fatalf("Tree is not empty")
+	}
+	if values := slices.Collect(snapshot.All()); len(values) != 10 {
+		t.Fatalf("Snapshot was modified: %v", values)
+	}
+}
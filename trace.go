@@ -0,0 +1,148 @@
+package redblack
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The direction of a rotation
+type Direction int
+
+const (
+	// The right child moves up and the node becomes its left child
+	RotateLeft Direction = 0
+	// The left child moves up and the node becomes its right child
+	RotateRight Direction = 1
+)
+
+func (d Direction) String() string {
+	if d == RotateLeft {
+		return "left"
+	}
+	return "right"
+}
+
+// A rotation around the pivot, which moves down
+type Rotation[V any] struct {
+	Direction Direction
+	Pivot     V
+}
+
+// One step of an insertion as recorded by [Tree.InsertTraced]
+type Step[V any] struct {
+	// 0 for the regular insert and otherwise the scenario from
+	// the package documentation that was applied
+	Scenario int
+	// The node the scenario was applied to
+	Node V
+	// The uncle of the node, unless it is a (black) leaf
+	Uncle    V
+	HasUncle bool
+	// Whether node, parent and grandparent form a triangle or a
+	// line. Empty if the node has no grandparent.
+	Relationship string
+	// The values whose node changed its color
+	Recolored []V
+	Rotations []Rotation[V]
+
+	// the tree after the step
+	snapshot *node[V, struct{}]
+}
+
+// Inserts the value like [Tree.Insert] and returns every step that
+// was taken to restore the properties.
+//
+// The first step is the regular insert of the red leaf, followed by
+// one step per scenario. Every step has a copy of the tree as it
+// looked afterwards. If the value already exists, the result is nil.
+func (t *Tree[V]) InsertTraced(v V) []Step[V] {
+	pos := t.seek(v)
+	if pos.match != nil {
+		return nil
+	}
+
+	var steps []Step[V]
+	trace := func(step Step[V]) {
+		step.snapshot = t.node.clone(nil)
+		steps = append(steps, step)
+	}
+
+	n := t.link(pos, v, struct{}{})
+	trace(n.step(0))
+	t.fixViolations(n, trace)
+
+	if t.node.color == Red {
+		step := t.node.step(1)
+		t.recolor(t.node, Black)
+		step.Recolored = []V{t.node.val}
+		trace(step)
+	}
+	return steps
+}
+
+// Describes the node before a scenario is applied to it
+func (n *node[V, D]) step(scenario int) Step[V] {
+	step := Step[V]{Scenario: scenario, Node: n.val}
+	if uncle, rel, ok := n.uncle(); ok {
+		step.Uncle = uncle.val
+		step.HasUncle = true
+		step.Relationship = rel.String()
+	} else if n.p != nil && n.p.p != nil {
+		step.Relationship = rel.String()
+	}
+	return step
+}
+
+// Describes the step in one line, e.g.
+//
+//	Scenario 2 at 3 (uncle 7, line): recolor 5, 9, 7
+func (s Step[V]) Description() string {
+	if s.Scenario == 0 {
+		return fmt.Sprintf("Insert %s", show(s.Node))
+	}
+
+	var details []string
+	if s.HasUncle {
+		details = append(details, "uncle "+show(s.Uncle))
+	} else if s.Relationship != "" {
+		details = append(details, "no uncle")
+	}
+	if s.Relationship != "" {
+		details = append(details, s.Relationship)
+	}
+
+	var changes []string
+	if len(s.Recolored) > 0 {
+		values := make([]string, len(s.Recolored))
+		for i, v := range s.Recolored {
+			values[i] = show(v)
+		}
+		changes = append(changes, "recolor "+strings.Join(values, ", "))
+	}
+	for _, r := range s.Rotations {
+		changes = append(changes, fmt.Sprintf("rotate %s at %s", r.Direction, show(r.Pivot)))
+	}
+
+	acc := fmt.Sprintf("Scenario %d at %s", s.Scenario, show(s.Node))
+	if len(details) > 0 {
+		acc += " (" + strings.Join(details, ", ") + ")"
+	}
+	return acc + ": " + strings.Join(changes, "; ")
+}
+
+// Writes the description and the tree after the step, see [Tree.Render]
+func (s Step[V]) Render(w io.Writer, opts RenderOptions) error {
+	if _, err := io.WriteString(w, s.Description()+"\n"); err != nil {
+		return err
+	}
+	return renderNode(w, s.snapshot, opts)
+}
+
+// Formats the step with its description and the tree after it
+func (s Step[V]) String() string {
+	var sb strings.Builder
+	// writing to a strings.Builder never fails
+	_ = s.Render(&sb, RenderOptions{})
+	return sb.String()
+}
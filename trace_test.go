@@ -0,0 +1,100 @@
+package redblack
+
+import (
+	"strings"
+	"testing"
+)
+
+func scenarios(steps []Step[int]) []int {
+	var acc []int
+	for _, s := range steps {
+		acc = append(acc, s.Scenario)
+	}
+	return acc
+}
+
+func TestInsertTracedRoot(t *testing.T) {
+	tree := MakeTree[int]()
+	steps := tree.InsertTraced(5)
+	if len(steps) != 2 || steps[0].Scenario != 0 || steps[1].Scenario != 1 {
+		t.Fatalf("Expected insert and scenario 1 but got %v", scenarios(steps))
+	}
+	if steps[0].snapshot.color != Red || steps[1].snapshot.color != Black {
+		t.Fatalf("Snapshots don't show the recolored root")
+	}
+	if tree.InsertTraced(5) != nil {
+		t.Fatalf("Inserting an existing value returned steps")
+	}
+}
+
+func TestInsertTracedRedUncle(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 8} {
+		tree.Insert(v)
+	}
+
+	steps := tree.InsertTraced(1)
+	if len(steps) != 3 || steps[1].Scenario != 2 || steps[2].Scenario != 1 {
+		t.Fatalf("Expected scenarios 0, 2, 1 but got %v", scenarios(steps))
+	}
+	s := steps[1]
+	if s.Node != 1 || !s.HasUncle || s.Uncle != 8 || s.Relationship != "line" {
+		t.Fatalf("Unexpected step %s", s.Description())
+	}
+	if len(s.Recolored) != 3 || len(s.Rotations) != 0 {
+		t.Fatalf("Unexpected changes %s", s.Description())
+	}
+	validateTreeProperties(t, tree.node)
+}
+
+func TestInsertTracedTriangle(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(3)
+
+	steps := tree.InsertTraced(4)
+	if len(steps) != 3 || steps[1].Scenario != 3 || steps[2].Scenario != 4 {
+		t.Fatalf("Expected scenarios 0, 3, 4 but got %v", scenarios(steps))
+	}
+	if r := steps[1].Rotations; len(r) != 1 || r[0].Direction != RotateLeft || r[0].Pivot != 3 {
+		t.Fatalf("Unexpected rotation %s", steps[1].Description())
+	}
+	if s := steps[2]; s.Node != 3 || s.HasUncle || s.Relationship != "line" {
+		t.Fatalf("Unexpected step %s", s.Description())
+	}
+	if r := steps[2].Rotations; len(r) != 1 || r[0].Direction != RotateRight || r[0].Pivot != 5 {
+		t.Fatalf("Unexpected rotation %s", steps[2].Description())
+	}
+	if steps[2].snapshot.val != 4 || tree.node.val != 4 {
+		t.Fatalf("4 did not become root")
+	}
+	validateTreeProperties(t, tree.node)
+	validateParentRefs(t, tree.node)
+	validateSizes(t, tree.node)
+}
+
+func TestInsertTracedSnapshotsAreCopies(t *testing.T) {
+	tree := MakeTree[int]()
+	steps := tree.InsertTraced(1)
+	tree.Insert(2)
+	tree.Insert(3)
+
+	if steps[1].snapshot.right != nil {
+		t.Fatalf("Snapshot was modified by later inserts")
+	}
+}
+
+func TestStepString(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(5)
+	tree.Insert(3)
+	steps := tree.InsertTraced(4)
+
+	expected := "Scenario 4 at 3 (no uncle, line): recolor 4, 5; rotate right at 5\n    4\n ┌──┴──┐\n(3)   (5)\n"
+	if steps[2].String() != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, steps[2].String())
+	}
+	if !strings.HasPrefix(steps[0].String(), "Insert 4\n") {
+		t.Fatalf("Unexpected insert step %s", steps[0].String())
+	}
+}
@@ -0,0 +1,194 @@
+package redblack
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// The invariant that is broken by a [Violation]
+type ViolationKind int
+
+const (
+	// A value is not smaller than the value that follows it
+	Unordered ViolationKind = iota
+	// The root is red
+	RedRoot
+	// A red node has a red child
+	RedRed
+	// The paths through the children of a node have a different
+	// number of black nodes
+	BlackHeight
+	// A child does not point back to its parent
+	ParentRef
+	// The size of a node is not the number of nodes in its subtree
+	Size
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case Unordered:
+		return "unordered"
+	case RedRoot:
+		return "red root"
+	case RedRed:
+		return "red-red"
+	case BlackHeight:
+		return "black height"
+	case ParentRef:
+		return "parent reference"
+	case Size:
+		return "size"
+	default:
+		return fmt.Sprintf("ViolationKind(%d)", int(k))
+	}
+}
+
+// A broken invariant at one node of the tree
+type Violation[V any] struct {
+	Kind  ViolationKind
+	Value V
+	// The way from the root to the node, e.g. root.left.right
+	Path   string
+	Reason string
+}
+
+func (v Violation[V]) String() string {
+	return fmt.Sprintf("%s at %s (%s): %s", v.Kind, v.Path, show(v.Value), v.Reason)
+}
+
+// Returned by Validate if the tree is broken. Lists every violation
+// in the order the nodes are visited from left to right.
+type ValidationError[V any] struct {
+	Violations []Violation[V]
+}
+
+func (e *ValidationError[V]) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "invalid red-black tree with %d violation(s)", len(e.Violations))
+	for _, v := range e.Violations {
+		sb.WriteString("\n\t")
+		sb.WriteString(v.String())
+	}
+	return sb.String()
+}
+
+// Checks that the tree is a valid Red-Black Tree. The values must be
+// strictly ordered, the root must be black, red nodes must not have
+// red children and all paths from a node to its leaves must have the
+// same number of black nodes. Additionally, every child must point
+// back to its parent and the sizes must match the subtrees.
+//
+// Returns a [*ValidationError] listing all violations or nil if
+// the tree is valid.
+func (t Tree[V]) Validate() error {
+	return t.validate(cmp.Compare[V])
+}
+
+func (t rbtree[V, D]) validate(compare func(a, b V) int) error {
+	return validateNodes[V](pointerNodes[V, D]{}, t.node, compare)
+}
+
+// The nodes of a tree as the validator sees them, so that [Tree] and
+// [ArenaTree] go through the same checks. The zero value of N is the
+// nil node.
+type validatedNodes[V any, N comparable] interface {
+	val(n N) V
+	left(n N) N
+	right(n N) N
+	parent(n N) N
+	color(n N) Color
+	size(n N) int
+}
+
+type pointerNodes[V any, D any] struct{}
+
+func (pointerNodes[V, D]) val(n *node[V, D]) V              { return n.val }
+func (pointerNodes[V, D]) left(n *node[V, D]) *node[V, D]   { return n.left }
+func (pointerNodes[V, D]) right(n *node[V, D]) *node[V, D]  { return n.right }
+func (pointerNodes[V, D]) parent(n *node[V, D]) *node[V, D] { return n.p }
+func (pointerNodes[V, D]) color(n *node[V, D]) Color        { return n.color }
+func (pointerNodes[V, D]) size(n *node[V, D]) int           { return n.size }
+
+func validateNodes[V any, N comparable](nodes validatedNodes[V, N], root N, compare func(a, b V) int) error {
+	var none N
+	if root == none {
+		return nil
+	}
+
+	v := validator[V, N]{nodes: nodes, compare: compare}
+	if nodes.color(root) != Black {
+		v.report(RedRoot, root, "root", "root is red")
+	}
+	if p := nodes.parent(root); p != none {
+		v.report(ParentRef, root, "root", fmt.Sprintf("root has parent %s", show(nodes.val(p))))
+	}
+	v.visit(root, "root")
+
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError[V]{Violations: v.violations}
+}
+
+type validator[V any, N comparable] struct {
+	nodes      validatedNodes[V, N]
+	compare    func(a, b V) int
+	prev       N
+	violations []Violation[V]
+}
+
+func (v *validator[V, N]) report(kind ViolationKind, n N, path, reason string) {
+	v.violations = append(v.violations, Violation[V]{Kind: kind, Value: v.nodes.val(n), Path: path, Reason: reason})
+}
+
+// Validates the subtree in order and returns its black height and
+// the number of nodes in it
+func (v *validator[V, N]) visit(n N, path string) (int, int) {
+	var none N
+	if n == none {
+		return 0, 0
+	}
+	t := v.nodes
+
+	for _, child := range []N{t.left(n), t.right(n)} {
+		if child == none {
+			continue
+		}
+		if t.parent(child) != n {
+			v.report(ParentRef, child, childPath(path, child == t.left(n)), fmt.Sprintf("parent is not %s", show(t.val(n))))
+		}
+		if t.color(n) == Red && t.color(child) == Red {
+			v.report(RedRed, n, path, fmt.Sprintf("red node has red child %s", show(t.val(child))))
+		}
+	}
+
+	left, leftSize := v.visit(t.left(n), childPath(path, true))
+
+	if v.prev != none && v.compare(t.val(v.prev), t.val(n)) >= 0 {
+		v.report(Unordered, n, path, fmt.Sprintf("%s is not smaller than %s", show(t.val(v.prev)), show(t.val(n))))
+	}
+	v.prev = n
+
+	right, rightSize := v.visit(t.right(n), childPath(path, false))
+
+	if left != right {
+		v.report(BlackHeight, n, path, fmt.Sprintf("left child has %d black nodes, but right child has %d", left, right))
+	}
+	size := leftSize + rightSize + 1
+	if t.size(n) != size {
+		v.report(Size, n, path, fmt.Sprintf("size is %d, but subtree has %d nodes", t.size(n), size))
+	}
+
+	if t.color(n) == Black {
+		return max(left, right) + 1, size
+	}
+	return max(left, right), size
+}
+
+func childPath(path string, left bool) string {
+	if left {
+		return path + ".left"
+	}
+	return path + ".right"
+}
@@ -0,0 +1,120 @@
+package redblack
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func validationErrorOf(t *testing.T, err error) *ValidationError[int] {
+	var verr *ValidationError[int]
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected ValidationError but got %v", err)
+	}
+	return verr
+}
+
+func TestValidateValidTree(t *testing.T) {
+	tree := MakeTree[int]()
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("Empty tree should be valid: %v", err)
+	}
+	for i := range 200 {
+		tree.Insert((i * 37) % 101)
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("Tree should be valid after inserting: %v", err)
+		}
+	}
+	for i := range 50 {
+		tree.Delete(i * 2)
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("Tree should be valid after deleting: %v", err)
+		}
+	}
+}
+
+func TestValidateRedRoot(t *testing.T) {
+	tree := MakeTree[int]()
+	tree.Insert(1)
+	tree.node.color = Red
+
+	verr := validationErrorOf(t, tree.Validate())
+	if len(verr.Violations) != 1 {
+		t.Fatalf("Expected one violation but got %v", verr.Violations)
+	}
+	v := verr.Violations[0]
+	if v.Kind != RedRoot || v.Value != 1 || v.Path != "root" {
+		t.Fatalf("Unexpected violation %s", v)
+	}
+}
+
+func TestValidateRedRed(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 7, 1} {
+		tree.Insert(v)
+	}
+	// 3 and 7 are black after inserting 1, 1 is red
+	tree.node.left.color = Red
+
+	verr := validationErrorOf(t, tree.Validate())
+	kinds := map[ViolationKind]Violation[int]{}
+	for _, v := range verr.Violations {
+		kinds[v.Kind] = v
+	}
+	if v, ok := kinds[RedRed]; !ok || v.Value != 3 || v.Path != "root.left" {
+		t.Fatalf("Expected red-red violation at 3: %v", verr)
+	}
+	if v, ok := kinds[BlackHeight]; !ok || v.Value != 5 || v.Path != "root" {
+		t.Fatalf("Expected black height violation at 5: %v", verr)
+	}
+}
+
+func TestValidateUnordered(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 7} {
+		tree.Insert(v)
+	}
+	tree.node.right.val = 4
+
+	verr := validationErrorOf(t, tree.Validate())
+	if len(verr.Violations) != 1 {
+		t.Fatalf("Expected one violation but got %v", verr)
+	}
+	v := verr.Violations[0]
+	if v.Kind != Unordered || v.Value != 4 || v.Path != "root.right" {
+		t.Fatalf("Unexpected violation %s", v)
+	}
+}
+
+func TestValidateParentRefsAndSizes(t *testing.T) {
+	tree := MakeTree[int]()
+	for _, v := range []int{5, 3, 7} {
+		tree.Insert(v)
+	}
+	tree.node.left.p = tree.node.right
+	tree.node.right.size = 2
+
+	verr := validationErrorOf(t, tree.Validate())
+	if len(verr.Violations) != 2 {
+		t.Fatalf("Expected two violations but got %v", verr)
+	}
+	if v := verr.Violations[0]; v.Kind != ParentRef || v.Value != 3 || v.Path != "root.left" {
+		t.Fatalf("Unexpected violation %s", v)
+	}
+	if v := verr.Violations[1]; v.Kind != Size || v.Value != 7 || v.Path != "root.right" {
+		t.Fatalf("Unexpected violation %s", v)
+	}
+	if !strings.Contains(verr.Error(), "size at root.right (7)") {
+		t.Fatalf("Error does not list the size violation: %s", verr)
+	}
+}
+
+func TestValidateTreeFunc(t *testing.T) {
+	tree := MakeTreeFunc(func(a, b int) int { return b - a })
+	for i := range 20 {
+		tree.Insert(i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("Tree should be valid with its own comparison: %v", err)
+	}
+}